@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -57,6 +58,10 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recorder is used to record/replay the requests made during this test, and is nil
+	// when running against Azure without recording
+	recorder *recording.Recorder
 }
 
 // BuildTestData generates some test data for the given resource
@@ -93,6 +98,11 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	if recorder := recorderForTest(t); recorder != nil {
+		testData.recorder = recorder
+		testData.pinRecordedValues()
+	}
+
 	return testData
 }
 
@@ -0,0 +1,37 @@
+package fakearm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// Client builds a Provider Client which sends all Resource Manager requests to this Server, without
+// authenticating against Azure Active Directory
+func (s *Server) Client(ctx context.Context) (*clients.Client, error) {
+	builder := clients.ClientBuilder{
+		AuthConfig: &authentication.Config{
+			ClientID:                         ClientId,
+			SubscriptionID:                   SubscriptionId,
+			TenantID:                         TenantId,
+			Environment:                      "public",
+			CustomResourceManagerEndpoint:    s.URL(),
+			AuthenticatedAsAServicePrincipal: true,
+		},
+		DisableTerraformPartnerID: true,
+		SkipProviderRegistration:  true,
+		SkipAuthentication:        true,
+		TerraformVersion:          "0.0.0",
+		Features:                  features.Default(),
+	}
+
+	client, err := clients.Build(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("building Client for the fake Resource Manager: %+v", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,115 @@
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const operationsPath = "/fakearm/operations"
+
+// operation is a Long Running Operation, which completes (running the `complete` func)
+// once it's been polled `remainingPolls` times
+type operation struct {
+	id             string
+	resourcePath   string
+	baseUri        string
+	remainingPolls int
+	complete       func()
+	completed      bool
+}
+
+// startOperation starts a new Long Running Operation for the specified path - the caller must hold the lock
+func (s *Server) startOperation(r *http.Request, path string, complete func()) *operation {
+	s.operationCount++
+	op := &operation{
+		id:             fmt.Sprintf("%d", s.operationCount),
+		resourcePath:   path,
+		baseUri:        fmt.Sprintf("http://%s", r.Host),
+		remainingPolls: s.PollsBeforeCompletion,
+		complete:       complete,
+	}
+	s.operations[op.id] = op
+
+	if op.remainingPolls <= 0 {
+		op.finish()
+	}
+
+	return op
+}
+
+func (o *operation) finish() {
+	if o.completed {
+		return
+	}
+	o.completed = true
+	o.complete()
+}
+
+// poll polls this operation, returning whether it's completed - the caller must hold the lock
+func (o *operation) poll() bool {
+	if !o.completed {
+		o.remainingPolls--
+		if o.remainingPolls <= 0 {
+			o.finish()
+		}
+	}
+
+	return o.completed
+}
+
+// writeHeaders writes the polling headers for this operation, the `Location` header is only
+// returned for a Delete, since for a PUT/PATCH a final GET is made against the Resource ID
+func (o *operation) writeHeaders(w http.ResponseWriter, includeLocation bool) {
+	w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("%s%s/%s/status?api-version=2020-01-01", o.baseUri, operationsPath, o.id))
+	if includeLocation {
+		w.Header().Set("Location", fmt.Sprintf("%s%s/%s/result?api-version=2020-01-01", o.baseUri, operationsPath, o.id))
+	}
+
+	// the Retry-After header is used as the polling delay, so there's no need to wait in tests
+	w.Header().Set("Retry-After", "0")
+}
+
+func (s *Server) serveOperation(w http.ResponseWriter, r *http.Request, path string) {
+	// /fakearm/operations/{id}/{status|result}
+	segments := strings.Split(strings.TrimPrefix(path, operationsPath+"/"), "/")
+	if r.Method != http.MethodGet || len(segments) != 2 {
+		writeNotFound(w, path)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	op, ok := s.operations[segments[0]]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+
+	completed := op.poll()
+	w.Header().Set("Retry-After", "0")
+
+	switch segments[1] {
+	case "status":
+		status := "InProgress"
+		if completed {
+			status = "Succeeded"
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name":   op.id,
+			"status": status,
+		})
+
+	case "result":
+		if !completed {
+			w.Header().Set("Location", fmt.Sprintf("%s%s", op.baseUri, r.URL.RequestURI()))
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeNotFound(w, path)
+	}
+}
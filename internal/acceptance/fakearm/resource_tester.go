@@ -0,0 +1,125 @@
+package fakearm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// ResourceTester runs the Create/Read/Update/Delete functions for a typed Resource against the
+// Server, planning and applying each configuration in the same manner as Terraform
+type ResourceTester struct {
+	client   *clients.Client
+	resource *schema.Resource
+	state    *terraform.InstanceState
+}
+
+// ResourceTester returns a ResourceTester for the specified Resource, which uses this Server
+func (s *Server) ResourceTester(ctx context.Context, resource sdk.Resource) (*ResourceTester, error) {
+	client, err := s.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := sdk.NewResourceWrapper(resource)
+	r, err := wrapper.Resource()
+	if err != nil {
+		return nil, fmt.Errorf("building Resource %q: %+v", resource.ResourceType(), err)
+	}
+
+	return &ResourceTester{
+		client:   client,
+		resource: r,
+	}, nil
+}
+
+// State returns the current state for this Resource, which is nil when it doesn't exist
+func (rt *ResourceTester) State() *terraform.InstanceState {
+	return rt.state
+}
+
+// Apply plans and then applies the specified configuration - creating, updating or recreating the
+// Resource as required - and returns the resulting state
+func (rt *ResourceTester) Apply(ctx context.Context, config map[string]interface{}) (*terraform.InstanceState, error) {
+	if diags := rt.resource.Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		return nil, fmt.Errorf("validating configuration: %+v", diagnosticsError(diags))
+	}
+
+	diff, err := rt.resource.Diff(ctx, rt.state, terraform.NewResourceConfigRaw(config), rt.client)
+	if err != nil {
+		return nil, fmt.Errorf("planning: %+v", err)
+	}
+
+	if diff == nil || diff.Empty() {
+		return rt.state, nil
+	}
+
+	state, diags := rt.resource.Apply(ctx, rt.state, diff, rt.client)
+	if state != nil && state.ID != "" {
+		rt.state = state
+	}
+	if diags.HasError() {
+		return rt.state, fmt.Errorf("applying: %+v", diagnosticsError(diags))
+	}
+
+	return rt.state, nil
+}
+
+// Refresh runs the Read function for the Resource and returns the resulting state, which is
+// nil when the Resource no longer exists
+func (rt *ResourceTester) Refresh(ctx context.Context) (*terraform.InstanceState, error) {
+	if rt.state == nil {
+		return nil, fmt.Errorf("the Resource must be created before it can be refreshed")
+	}
+
+	state, diags := rt.resource.RefreshWithoutUpgrade(ctx, rt.state, rt.client)
+	if diags.HasError() {
+		return rt.state, fmt.Errorf("refreshing: %+v", diagnosticsError(diags))
+	}
+
+	if state == nil || state.ID == "" {
+		rt.state = nil
+		return nil, nil
+	}
+
+	rt.state = state
+	return rt.state, nil
+}
+
+// Destroy runs the Delete function for the Resource
+func (rt *ResourceTester) Destroy(ctx context.Context) error {
+	if rt.state == nil {
+		return fmt.Errorf("the Resource must be created before it can be destroyed")
+	}
+
+	_, diags := rt.resource.Apply(ctx, rt.state, &terraform.InstanceDiff{Destroy: true}, rt.client)
+	if diags.HasError() {
+		return fmt.Errorf("destroying: %+v", diagnosticsError(diags))
+	}
+
+	rt.state = nil
+	return nil
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	messages := make([]string, 0)
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		message := d.Summary
+		if d.Detail != "" {
+			message = fmt.Sprintf("%s: %s", d.Summary, d.Detail)
+		}
+		messages = append(messages, message)
+	}
+
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
@@ -0,0 +1,243 @@
+package fakearm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestResourceTester(t *testing.T) {
+	server := NewServer()
+	server.PollsBeforeCompletion = 2
+	defer server.Close()
+
+	ctx := context.TODO()
+	tester, err := server.ResourceTester(ctx, testResourceGroupResource{})
+	if err != nil {
+		t.Fatalf("building ResourceTester: %+v", err)
+	}
+
+	state, err := tester.Apply(ctx, map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"env": "test",
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating: %+v", err)
+	}
+	expectedId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", SubscriptionId)
+	if state.ID != expectedId {
+		t.Fatalf("expected the ID to be %q but got %q", expectedId, state.ID)
+	}
+
+	state, err = tester.Apply(ctx, map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"env": "updated",
+		},
+	})
+	if err != nil {
+		t.Fatalf("updating: %+v", err)
+	}
+	if v := state.Attributes["tags.env"]; v != "updated" {
+		t.Fatalf("expected the tag `env` to be `updated` but got %q", v)
+	}
+
+	if err := tester.Destroy(ctx); err != nil {
+		t.Fatalf("destroying: %+v", err)
+	}
+	if _, exists := server.Get(expectedId); exists {
+		t.Fatalf("expected %q to have been deleted", expectedId)
+	}
+}
+
+type testResourceGroupResource struct{}
+
+type testResourceGroupModel struct {
+	Name     string            `tfschema:"name"`
+	Location string            `tfschema:"location"`
+	Tags     map[string]string `tfschema:"tags"`
+}
+
+var _ sdk.ResourceWithUpdate = testResourceGroupResource{}
+
+func (testResourceGroupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"location": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"tags": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (testResourceGroupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (testResourceGroupResource) ModelObject() interface{} {
+	return &testResourceGroupModel{}
+}
+
+func (testResourceGroupResource) ResourceType() string {
+	return "fakearm_resource_group"
+}
+
+func (testResourceGroupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ResourceGroupID
+}
+
+func (r testResourceGroupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
+
+			var model testResourceGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewResourceGroupID(metadata.Client.Account.SubscriptionId, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, r.expand(model)); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r testResourceGroupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
+
+			id, err := parse.ResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			return metadata.Encode(&testResourceGroupModel{
+				Name:     id.ResourceGroup,
+				Location: *resp.Location,
+				Tags:     flattenTags(resp.Tags),
+			})
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r testResourceGroupResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
+
+			id, err := parse.ResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model testResourceGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, r.expand(model)); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (testResourceGroupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
+
+			id, err := parse.ResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, "")
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (testResourceGroupResource) expand(model testResourceGroupModel) resources.Group {
+	return resources.Group{
+		Location: utils.String(model.Location),
+		Tags:     expandTags(model.Tags),
+	}
+}
+
+func expandTags(input map[string]string) map[string]*string {
+	out := make(map[string]*string)
+	for k, v := range input {
+		out[k] = utils.String(v)
+	}
+	return out
+}
+
+func flattenTags(input map[string]*string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range input {
+		if v != nil {
+			out[k] = *v
+		}
+	}
+	return out
+}
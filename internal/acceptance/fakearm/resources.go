@@ -0,0 +1,122 @@
+package fakearm
+
+import (
+	"strings"
+)
+
+// resourceKey returns the key used to store a resource, since Resource IDs are case-insensitive
+func resourceKey(id string) string {
+	return strings.ToLower(strings.TrimSuffix(id, "/"))
+}
+
+// isCollection returns whether the specified path refers to a collection of resources (for example
+// `/subscriptions/{id}/resourceGroups` or `.../providers/Microsoft.Example/things`) rather than a resource
+func isCollection(path string) bool {
+	segments := nonProviderSegments(path)
+	if len(segments) > 0 && strings.EqualFold(segments[len(segments)-1], "providers") {
+		return true
+	}
+
+	// once the `providers/{namespace}` segments are removed, Resource IDs are comprised of key/value pairs
+	return len(segments)%2 == 1
+}
+
+// nonProviderSegments returns the segments of the specified path, excluding any `providers/{namespace}` pairs
+func nonProviderSegments(path string) []string {
+	segments := make([]string, 0)
+	split := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(split); i++ {
+		if strings.EqualFold(split[i], "providers") && i+1 < len(split) {
+			i++
+			continue
+		}
+		segments = append(segments, split[i])
+	}
+	return segments
+}
+
+// resourceType returns the Resource Type (e.g. `Microsoft.Example/things/nested`) for the specified Resource ID
+func resourceType(id string) string {
+	split := strings.Split(strings.Trim(id, "/"), "/")
+
+	namespace := ""
+	types := make([]string, 0)
+	offset := 0
+	for i := 0; i < len(split); i++ {
+		if strings.EqualFold(split[i], "providers") && i+1 < len(split) {
+			namespace = split[i+1]
+			types = make([]string, 0)
+			offset = i + 2
+			i++
+			continue
+		}
+
+		// following the namespace the segments alternate between the type and the name
+		if namespace != "" && (i-offset)%2 == 0 {
+			types = append(types, split[i])
+		}
+	}
+
+	if namespace == "" {
+		if len(split) == 4 && strings.EqualFold(split[2], "resourceGroups") {
+			return "Microsoft.Resources/resourceGroups"
+		}
+		return ""
+	}
+
+	return strings.Join(append([]string{namespace}, types...), "/")
+}
+
+// withResourceMetadata returns the resource with the fields populated by Resource Manager (the `id`,
+// `name`, `type` and the `provisioningState` within the `properties` block, where present)
+func withResourceMetadata(id string, resource map[string]interface{}, provisioningState string) map[string]interface{} {
+	out := make(map[string]interface{})
+	for k, v := range resource {
+		out[k] = v
+	}
+
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	out["id"] = "/" + strings.Trim(id, "/")
+	out["name"] = segments[len(segments)-1]
+	if t := resourceType(id); t != "" {
+		out["type"] = t
+	}
+
+	setProvisioningState(out, provisioningState)
+	return out
+}
+
+func setProvisioningState(resource map[string]interface{}, provisioningState string) {
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	properties["provisioningState"] = provisioningState
+}
+
+// mergePatch applies the patch to the existing value, as defined in RFC 7386 (JSON Merge Patch)
+func mergePatch(existing interface{}, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	existingMap, ok := existing.(map[string]interface{})
+	out := make(map[string]interface{})
+	if ok {
+		for k, v := range existingMap {
+			out[k] = v
+		}
+	}
+
+	for k, v := range patchMap {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = mergePatch(out[k], v)
+	}
+
+	return out
+}
@@ -0,0 +1,317 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// SubscriptionId is the Subscription ID used by Clients built against the Server
+	SubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// TenantId is the Tenant ID used by Clients built against the Server
+	TenantId = "11111111-1111-1111-1111-111111111111"
+
+	// ClientId is the Client ID used by Clients built against the Server
+	ClientId = "22222222-2222-2222-2222-222222222222"
+)
+
+// Server is an in-process fake of Azure Resource Manager, implementing generic PUT/GET/PATCH/DELETE
+// semantics for any Resource ID, including Long Running Operations (polled via the `Azure-AsyncOperation`
+// and `Location` headers) - which allows the CRUD functions for a Resource to be tested without Azure.
+type Server struct {
+	// PollsBeforeCompletion is the number of times a Long Running Operation reports as in progress
+	// before it completes, which defaults to 1
+	PollsBeforeCompletion int
+
+	// LongRunningOperations specifies whether PUT, PATCH and DELETE requests should complete
+	// asynchronously (as a Long Running Operation) or synchronously, which defaults to true
+	LongRunningOperations bool
+
+	server *httptest.Server
+
+	lock           sync.Mutex
+	resources      map[string]map[string]interface{}
+	operations     map[string]*operation
+	handlers       []handler
+	requests       []string
+	operationCount int
+}
+
+type handler struct {
+	method  string
+	pattern *regexp.Regexp
+	handler http.HandlerFunc
+}
+
+// NewServer starts a new fake Resource Manager Server, which must be closed once it's finished with
+func NewServer() *Server {
+	s := &Server{
+		PollsBeforeCompletion: 1,
+		LongRunningOperations: true,
+		resources:             make(map[string]map[string]interface{}),
+		operations:            make(map[string]*operation),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the Server, which should be used as the Resource Manager Endpoint
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the Server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// HandleFunc registers a custom handler for requests using the specified HTTP Method whose path matches
+// the specified regular expression - which takes precedence over the generic Resource Manager semantics,
+// for example to implement a POST action or to return an error
+func (s *Server) HandleFunc(method string, pathPattern string, fn http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.handlers = append(s.handlers, handler{
+		method:  strings.ToUpper(method),
+		pattern: regexp.MustCompile(pathPattern),
+		handler: fn,
+	})
+}
+
+// Put stores the specified resource in the Server, for example to seed a parent resource
+func (s *Server) Put(id string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.resources[resourceKey(id)] = withResourceMetadata(id, resource, "Succeeded")
+}
+
+// Get returns the resource with the specified Resource ID stored in the Server, if it exists
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource, ok := s.resources[resourceKey(id)]
+	return resource, ok
+}
+
+// Requests returns the list of requests made to the Server, in the format `METHOD /path`
+func (s *Server) Requests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	out := make([]string, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	s.lock.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, path))
+	var custom http.HandlerFunc
+	for _, h := range s.handlers {
+		if h.method == r.Method && h.pattern.MatchString(path) {
+			custom = h.handler
+			break
+		}
+	}
+	s.lock.Unlock()
+
+	if custom != nil {
+		custom(w, r)
+		return
+	}
+
+	if strings.HasPrefix(path, operationsPath) {
+		s.serveOperation(w, r, path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, path)
+	case http.MethodPut:
+		s.put(w, r, path)
+	case http.MethodPatch:
+		s.patch(w, r, path)
+	case http.MethodDelete:
+		s.delete(w, r, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("the method %q is not supported for %q", r.Method, path))
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, path string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if isCollection(path) {
+		prefix := resourceKey(path) + "/"
+		values := make([]interface{}, 0)
+		for key, resource := range s.resources {
+			if strings.HasPrefix(key, prefix) && !strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+				values = append(values, resource)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"value": values,
+		})
+		return
+	}
+
+	resource, ok := s.resources[resourceKey(path)]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, path string) {
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := resourceKey(path)
+	_, exists := s.resources[key]
+	status := http.StatusCreated
+	provisioningState := "Creating"
+	if exists {
+		status = http.StatusOK
+		provisioningState = "Updating"
+	}
+
+	if !s.LongRunningOperations {
+		resource := withResourceMetadata(path, body, "Succeeded")
+		s.resources[key] = resource
+		writeJSON(w, status, resource)
+		return
+	}
+
+	resource := withResourceMetadata(path, body, provisioningState)
+	s.resources[key] = resource
+	op := s.startOperation(r, path, func() {
+		setProvisioningState(resource, "Succeeded")
+	})
+	op.writeHeaders(w, false)
+	writeJSON(w, status, resource)
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, path string) {
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := resourceKey(path)
+	existing, ok := s.resources[key]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+
+	merged := mergePatch(existing, body).(map[string]interface{})
+	if !s.LongRunningOperations {
+		resource := withResourceMetadata(path, merged, "Succeeded")
+		s.resources[key] = resource
+		writeJSON(w, http.StatusOK, resource)
+		return
+	}
+
+	resource := withResourceMetadata(path, merged, "Updating")
+	s.resources[key] = resource
+	op := s.startOperation(r, path, func() {
+		setProvisioningState(resource, "Succeeded")
+	})
+	op.writeHeaders(w, false)
+	writeJSON(w, http.StatusAccepted, resource)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, path string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := resourceKey(path)
+	resource, ok := s.resources[key]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// deleting a resource also deletes any nested resources
+	removeResources := func() {
+		for k := range s.resources {
+			if k == key || strings.HasPrefix(k, key+"/") {
+				delete(s.resources, k)
+			}
+		}
+	}
+
+	if !s.LongRunningOperations {
+		removeResources()
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	setProvisioningState(resource, "Deleting")
+	op := s.startOperation(r, path, removeResources)
+	op.writeHeaders(w, true)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	contents, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %+v", err)
+	}
+
+	body := make(map[string]interface{})
+	if len(contents) == 0 {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(contents, &body); err != nil {
+		return nil, fmt.Errorf("parsing request body: %+v", err)
+	}
+
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body) // nolint: errcheck
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", path))
+}
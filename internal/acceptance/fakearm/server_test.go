@@ -0,0 +1,118 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServerLongRunningOperations(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	id := "/subscriptions/" + SubscriptionId + "/resourceGroups/example/providers/Microsoft.Example/things/first"
+	resp := doRequest(t, http.MethodPut, server.URL()+id, `{"location": "westeurope", "properties": {"enabled": true}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a 201 but got %d", resp.StatusCode)
+	}
+	pollingUri := resp.Header.Get("Azure-AsyncOperation")
+	if pollingUri == "" {
+		t.Fatalf("expected an Azure-AsyncOperation header")
+	}
+
+	resource, _ := server.Get(id)
+	if v := resource["properties"].(map[string]interface{})["provisioningState"]; v != "Creating" {
+		t.Fatalf("expected the provisioningState to be `Creating` prior to polling but got %q", v)
+	}
+	if v := resource["type"]; v != "Microsoft.Example/things" {
+		t.Fatalf("expected the type to be `Microsoft.Example/things` but got %q", v)
+	}
+
+	body := decode(t, doRequest(t, http.MethodGet, pollingUri, ""))
+	if body["status"] != "Succeeded" {
+		t.Fatalf("expected the operation to have Succeeded but got %q", body["status"])
+	}
+
+	resp = doRequest(t, http.MethodPut, server.URL()+id, `{"location": "westeurope", "properties": {"enabled": true}}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 when updating an existing resource but got %d", resp.StatusCode)
+	}
+	doRequest(t, http.MethodGet, resp.Header.Get("Azure-AsyncOperation"), "")
+
+	resp = doRequest(t, http.MethodPatch, server.URL()+id, `{"properties": {"enabled": null, "count": 2}}`)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected a 202 but got %d", resp.StatusCode)
+	}
+	doRequest(t, http.MethodGet, resp.Header.Get("Azure-AsyncOperation"), "")
+	resource, _ = server.Get(id)
+	properties := resource["properties"].(map[string]interface{})
+	if _, ok := properties["enabled"]; ok || properties["count"] != float64(2) || properties["provisioningState"] != "Succeeded" {
+		t.Fatalf("expected the PATCH to be merged into the existing resource but got %+v", properties)
+	}
+
+	list := decode(t, doRequest(t, http.MethodGet, server.URL()+strings.TrimSuffix(id, "/first")+"?api-version=2020-01-01", ""))
+	if values := list["value"].([]interface{}); len(values) != 1 {
+		t.Fatalf("expected 1 item in the list but got %d", len(values))
+	}
+
+	resp = doRequest(t, http.MethodDelete, server.URL()+id, "")
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected a 202 but got %d", resp.StatusCode)
+	}
+	if resp := doRequest(t, http.MethodGet, resp.Header.Get("Location"), ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected a 204 once the delete completed but got %d", resp.StatusCode)
+	}
+
+	resp = doRequest(t, http.MethodGet, server.URL()+id, "")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 after the delete but got %d", resp.StatusCode)
+	}
+	if code := decode(t, resp)["error"].(map[string]interface{})["code"]; code != "ResourceNotFound" {
+		t.Fatalf("expected the error code `ResourceNotFound` but got %q", code)
+	}
+}
+
+func TestIsCollection(t *testing.T) {
+	testData := map[string]bool{
+		"/subscriptions/abc":                        false,
+		"/subscriptions/abc/resourceGroups":         true,
+		"/subscriptions/abc/resourceGroups/example": false,
+		"/subscriptions/abc/providers":              true,
+		"/subscriptions/abc/resourceGroups/example/providers/Microsoft.Example/things":                                                true,
+		"/subscriptions/abc/resourceGroups/example/providers/Microsoft.Example/things/":                                               true,
+		"/subscriptions/abc/resourceGroups/example/providers/Microsoft.Example/things/first":                                          false,
+		"/subscriptions/abc/resourceGroups/example/providers/Microsoft.Example/things/first/nested":                                   true,
+		"/subscriptions/abc/resourceGroups/example/providers/Microsoft.Example/things/first/providers/Microsoft.Other/extensions/ext": false,
+	}
+
+	for input, expected := range testData {
+		if actual := isCollection(input); actual != expected {
+			t.Fatalf("expected isCollection to be %t for %q but got %t", expected, input, actual)
+		}
+	}
+}
+
+func doRequest(t *testing.T, method, uri, body string) *http.Response {
+	req, err := http.NewRequest(method, uri, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sending %s %s: %+v", method, uri, err)
+	}
+
+	return resp
+}
+
+func decode(t *testing.T, resp *http.Response) map[string]interface{} {
+	defer resp.Body.Close()
+
+	out := make(map[string]interface{})
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %+v", err)
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package acceptance
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
+)
+
+var testRecorders = struct {
+	sync.Mutex
+	byTestName map[string]*recording.Recorder
+}{
+	byTestName: make(map[string]*recording.Recorder),
+}
+
+// recorderForTest returns the Recorder for this test when recording or replaying requests (or nil
+// when running against Azure) - since a test can build TestData more than once, a single
+// Recorder (and Cassette) is used per test
+func recorderForTest(t *testing.T) *recording.Recorder {
+	mode := recording.CurrentMode()
+	if mode == recording.ModeLive {
+		return nil
+	}
+
+	testRecorders.Lock()
+	defer testRecorders.Unlock()
+
+	if existing, ok := testRecorders.byTestName[t.Name()]; ok {
+		return existing
+	}
+
+	var cassette *recording.Cassette
+	var inner autorest.Sender
+	if mode == recording.ModeRecord {
+		// the random values are pinned by the first call to BuildTestData for this test
+		cassette = &recording.Cassette{
+			Name:      t.Name(),
+			Variables: map[string]string{},
+		}
+		inner = sender.BuildSender("AzureRM")
+	} else {
+		recording.ConfigureReplayEnvironment()
+
+		var err error
+		cassette, err = recording.LoadCassette(t.Name())
+		if err != nil {
+			t.Fatalf("loading Cassette: %+v", err)
+		}
+	}
+
+	recorder, err := recording.NewRecorder(cassette, mode, inner)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	recorder.Register()
+	testRecorders.byTestName[t.Name()] = recorder
+
+	t.Cleanup(func() {
+		recorder.Deregister()
+
+		testRecorders.Lock()
+		delete(testRecorders.byTestName, t.Name())
+		testRecorders.Unlock()
+
+		if mode == recording.ModeReplay {
+			if unused := recorder.Unused(); unused > 0 {
+				t.Logf("[DEBUG] %d recorded interactions in Cassette %q were not replayed", unused, cassette.Name)
+			}
+			return
+		}
+
+		// a failed test would produce a Cassette which can't be replayed successfully
+		if t.Failed() {
+			t.Logf("[DEBUG] Test failed - not saving Cassette %q", cassette.Name)
+			return
+		}
+
+		if err := cassette.Save(); err != nil {
+			t.Errorf("saving Cassette: %+v", err)
+		}
+	})
+
+	return recorder
+}
+
+// pinRecordedValues ensures that the random values and regions used for this test match the
+// Cassette being recorded/replayed, since these form part of the URIs being requested
+func (td *TestData) pinRecordedValues() {
+	cassette := td.recorder.Cassette()
+
+	if td.recorder.Mode() == recording.ModeRecord {
+		// the first TestData built for this test pins its values into the Cassette, any subsequent
+		// TestData adopts them so that the URIs recorded are consistent across the whole test
+		if cassette.RandomString == "" {
+			cassette.RandomInteger = td.RandomInteger
+			cassette.RandomString = td.RandomString
+			cassette.Variables["ARM_TEST_LOCATION"] = td.Locations.Primary
+			cassette.Variables["ARM_TEST_LOCATION_ALT"] = td.Locations.Secondary
+			cassette.Variables["ARM_TEST_LOCATION_ALT2"] = td.Locations.Ternary
+			return
+		}
+	}
+
+	td.RandomInteger = cassette.RandomInteger
+	td.RandomString = cassette.RandomString
+	td.Locations = Regions{
+		Primary:   cassette.Variables["ARM_TEST_LOCATION"],
+		Secondary: cassette.Variables["ARM_TEST_LOCATION_ALT"],
+		Ternary:   cassette.Variables["ARM_TEST_LOCATION_ALT2"],
+	}
+
+	if td.recorder.Mode() == recording.ModeReplay {
+		td.Subscriptions = Subscriptions{
+			Primary:   recording.PlaceholderSubscriptionId,
+			Secondary: recording.PlaceholderSubscriptionId,
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Cassette contains the interactions recorded for a single Acceptance Test, alongside
+// the random values and variables used when recording, so that these can be pinned
+// when the Cassette is replayed
+type Cassette struct {
+	// Name is the name of the Test which this Cassette was recorded for
+	Name string `json:"name"`
+
+	// RandomInteger is the value of `TestData.RandomInteger` used when recording
+	RandomInteger int `json:"randomInteger"`
+
+	// RandomString is the value of `TestData.RandomString` used when recording
+	RandomString string `json:"randomString"`
+
+	// Variables contains the (non-sensitive) Environment Variables used when recording,
+	// such as the Azure Regions which were tested against
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// Interactions is the ordered list of requests made (and the responses returned)
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+var cassetteNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// CassettePath returns the path to the Cassette for the specified Test Name
+func CassettePath(testName string) string {
+	fileName := cassetteNameInvalidChars.ReplaceAllString(testName, "_")
+	return filepath.Join(Directory(), fmt.Sprintf("%s.json", fileName))
+}
+
+// LoadCassette loads the Cassette for the specified Test Name from disk
+func LoadCassette(testName string) (*Cassette, error) {
+	path := CassettePath(testName)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading Cassette %q: %+v", path, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		return nil, fmt.Errorf("parsing Cassette %q: %+v", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the Cassette to disk, creating the recordings directory if necessary
+func (c *Cassette) Save() error {
+	path := CassettePath(c.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for Cassette %q: %+v", path, err)
+	}
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing Cassette %q: %+v", path, err)
+	}
+
+	if err := os.WriteFile(path, contents, 0o644); err != nil {
+		return fmt.Errorf("writing Cassette %q: %+v", path, err)
+	}
+
+	return nil
+}
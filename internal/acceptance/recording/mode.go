@@ -0,0 +1,86 @@
+package recording
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	// ModeLive sends requests to Azure without recording them - this is the default
+	ModeLive Mode = "live"
+
+	// ModeRecord sends requests to Azure and records each interaction into a Cassette
+	ModeRecord Mode = "record"
+
+	// ModeReplay replays the interactions from a previously recorded Cassette without
+	// making any requests to Azure (or authenticating against Azure Active Directory)
+	ModeReplay Mode = "replay"
+)
+
+const (
+	// ModeEnvVar is the Environment Variable used to toggle the Recording Mode
+	ModeEnvVar = "ARM_TEST_RECORDING_MODE"
+
+	// DirectoryEnvVar is the Environment Variable used to override the directory
+	// containing the Cassettes, which defaults to `testdata/recordings`
+	DirectoryEnvVar = "ARM_TEST_RECORDINGS_DIR"
+)
+
+const (
+	// PlaceholderSubscriptionId is the Subscription ID written into Cassettes in place of
+	// the Subscription ID used when recording, and which is used when replaying
+	PlaceholderSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// PlaceholderTenantId is the Tenant ID written into Cassettes in place of the Tenant ID
+	// used when recording, and which is used when replaying
+	PlaceholderTenantId = "11111111-1111-1111-1111-111111111111"
+
+	// PlaceholderClientId is the Client ID used when replaying
+	PlaceholderClientId = "22222222-2222-2222-2222-222222222222"
+)
+
+// CurrentMode returns the Recording Mode configured via the `ARM_TEST_RECORDING_MODE`
+// Environment Variable, defaulting to ModeLive when this isn't set
+func CurrentMode() Mode {
+	switch strings.ToLower(os.Getenv(ModeEnvVar)) {
+	case string(ModeRecord):
+		return ModeRecord
+	case string(ModeReplay):
+		return ModeReplay
+	}
+
+	return ModeLive
+}
+
+// Directory returns the directory which Cassettes should be read from/written to
+func Directory() string {
+	if v := os.Getenv(DirectoryEnvVar); v != "" {
+		return v
+	}
+
+	return "testdata/recordings"
+}
+
+var configureReplayEnvironmentOnce sync.Once
+
+// ConfigureReplayEnvironment populates the Environment Variables required to configure the Provider
+// with placeholder values (where these aren't already set), since no credentials are required to
+// replay a Cassette
+func ConfigureReplayEnvironment() {
+	configureReplayEnvironmentOnce.Do(func() {
+		values := map[string]string{
+			"ARM_SUBSCRIPTION_ID": PlaceholderSubscriptionId,
+			"ARM_TENANT_ID":       PlaceholderTenantId,
+			"ARM_CLIENT_ID":       PlaceholderClientId,
+			"ARM_CLIENT_SECRET":   "replay",
+		}
+		for k, v := range values {
+			if os.Getenv(k) == "" {
+				os.Setenv(k, v)
+			}
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package recording
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// Recorder is an autorest.Sender which either records the requests sent to (and the responses
+// returned from) Azure into a Cassette, or replays the responses from a previously recorded Cassette
+type Recorder struct {
+	mode     Mode
+	inner    autorest.Sender
+	cassette *Cassette
+	scrubs   []scrub
+
+	lock sync.Mutex
+
+	// interactions is a map of the request key to the indexes of the matching interactions
+	interactions map[string][]int
+
+	// consumed is a map of the request key to the number of matching interactions which have been replayed
+	consumed map[string]int
+
+	// paths is the set of (normalized) URL paths which have been requested via this Recorder
+	paths map[string]struct{}
+}
+
+type scrub struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// headersToSkip are the response headers which are never written into a Cassette
+var headersToSkip = map[string]struct{}{
+	"Authorization": {},
+	"Set-Cookie":    {},
+}
+
+// NewRecorder returns a Recorder for the specified Cassette. When recording, requests are sent using the
+// `inner` Sender - when replaying the `inner` Sender is unused and can be nil.
+func NewRecorder(cassette *Cassette, mode Mode, inner autorest.Sender) (*Recorder, error) {
+	if mode != ModeRecord && mode != ModeReplay {
+		return nil, fmt.Errorf("a Recorder can only be used in %q or %q mode but got %q", ModeRecord, ModeReplay, mode)
+	}
+	if mode == ModeRecord && inner == nil {
+		return nil, fmt.Errorf("an inner Sender must be specified when recording")
+	}
+
+	r := &Recorder{
+		mode:         mode,
+		inner:        inner,
+		cassette:     cassette,
+		scrubs:       scrubsFromEnvironment(),
+		interactions: make(map[string][]int),
+		consumed:     make(map[string]int),
+		paths:        make(map[string]struct{}),
+	}
+	for i, interaction := range cassette.Interactions {
+		key, path, err := requestKey(interaction.Request.Method, interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing interaction %d from Cassette %q: %+v", i, cassette.Name, err)
+		}
+		r.interactions[key] = append(r.interactions[key], i)
+		r.paths[path] = struct{}{}
+	}
+
+	return r, nil
+}
+
+// Cassette returns the Cassette being recorded into/replayed from
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// Mode returns the Mode this Recorder is running in
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body for %s %s: %+v", req.Method, req.URL.String(), err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		requestBody = body
+	}
+
+	resp, err := r.inner.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	var responseBody []byte
+	if resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading response body for %s %s: %+v", req.Method, req.URL.String(), err)
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		responseBody = body
+	}
+
+	headers := make(map[string][]string)
+	for k, values := range resp.Header {
+		if _, skip := headersToSkip[http.CanonicalHeaderKey(k)]; skip {
+			continue
+		}
+		scrubbed := make([]string, 0, len(values))
+		for _, v := range values {
+			scrubbed = append(scrubbed, r.scrub(v))
+		}
+		headers[k] = scrubbed
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrub(req.URL.String()),
+			Body:   r.scrub(redactBody(string(requestBody))),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       r.scrub(redactBody(string(responseBody))),
+		},
+	}
+	key, path, err := requestKey(interaction.Request.Method, interaction.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.interactions[key] = append(r.interactions[key], len(r.cassette.Interactions)-1)
+	r.paths[path] = struct{}{}
+	r.lock.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	key, _, err := requestKey(req.Method, r.scrub(req.URL.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	indexes, ok := r.interactions[key]
+	if !ok {
+		r.lock.Unlock()
+		return nil, fmt.Errorf("no interaction was recorded in Cassette %q for %s", r.cassette.Name, key)
+	}
+
+	// requests are replayed in the order they were recorded, however once all of the matching interactions
+	// have been consumed the last response is returned, since Terraform can refresh the same resource more
+	// times than it did during recording (for example when a test step fails)
+	position := r.consumed[key]
+	if position >= len(indexes) {
+		position = len(indexes) - 1
+	}
+	r.consumed[key]++
+	interaction := r.cassette.Interactions[indexes[position]]
+	r.lock.Unlock()
+
+	header := make(http.Header)
+	for k, values := range interaction.Response.Headers {
+		for _, v := range values {
+			header.Add(k, v)
+		}
+	}
+
+	// the polling delay for long running operations comes from the `Retry-After` header, since
+	// the responses are already available there's no need to wait when replaying them
+	if header.Get(autorest.HeaderRetryAfter) != "" {
+		header.Set(autorest.HeaderRetryAfter, "0")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// handles returns whether this Recorder should handle the specified request, which is used
+// to route requests made by the shared Test Client to the Cassette for the relevant test
+func (r *Recorder) handles(req *http.Request) bool {
+	key, path, err := requestKey(req.Method, r.scrub(req.URL.String()))
+	if err != nil {
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.mode == ModeReplay {
+		_, ok := r.interactions[key]
+		return ok
+	}
+
+	_, ok := r.paths[path]
+	return ok
+}
+
+func (r *Recorder) scrub(input string) string {
+	for _, s := range r.scrubs {
+		input = s.pattern.ReplaceAllString(input, s.replacement)
+	}
+	return input
+}
+
+// scrubsFromEnvironment returns the list of identifiers which should be replaced
+// with placeholder values, so that these aren't written into Cassettes
+func scrubsFromEnvironment() []scrub {
+	values := map[string]string{
+		"ARM_SUBSCRIPTION_ID":          PlaceholderSubscriptionId,
+		"ARM_TEST_SUBSCRIPTION_ID_ALT": PlaceholderSubscriptionId,
+		"ARM_SUBSCRIPTION_ID_ALT":      PlaceholderSubscriptionId,
+		"ARM_TENANT_ID":                PlaceholderTenantId,
+		"ARM_CLIENT_ID":                PlaceholderClientId,
+	}
+
+	scrubs := make([]scrub, 0)
+	for envVar, replacement := range values {
+		value := os.Getenv(envVar)
+		if value == "" || strings.EqualFold(value, replacement) {
+			continue
+		}
+
+		scrubs = append(scrubs, scrub{
+			pattern:     regexp.MustCompile(fmt.Sprintf("(?i)%s", regexp.QuoteMeta(value))),
+			replacement: replacement,
+		})
+	}
+	return scrubs
+}
+
+// requestKey returns the key used to match a request against the recorded interactions, which
+// is comprised of the HTTP Method and the normalized URL, alongside the normalized URL Path
+func requestKey(method, input string) (key string, path string, err error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing URL %q: %+v", input, err)
+	}
+
+	// Resource Manager URIs are case-insensitive, and `Encode` sorts the query string by key
+	path = strings.ToLower(fmt.Sprintf("%s%s", uri.Host, strings.TrimSuffix(uri.EscapedPath(), "/")))
+	key = fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	if query := uri.Query().Encode(); query != "" {
+		key = fmt.Sprintf("%s?%s", key, query)
+	}
+
+	return key, path, nil
+}
+
+var activeRecorders = struct {
+	sync.Mutex
+	recorders map[*Recorder]struct{}
+}{
+	recorders: make(map[*Recorder]struct{}),
+}
+
+// Register makes this Recorder available to the Sender returned from SharedSender
+func (r *Recorder) Register() {
+	activeRecorders.Lock()
+	defer activeRecorders.Unlock()
+	activeRecorders.recorders[r] = struct{}{}
+}
+
+// Deregister removes this Recorder from the Sender returned from SharedSender
+func (r *Recorder) Deregister() {
+	activeRecorders.Lock()
+	defer activeRecorders.Unlock()
+	delete(activeRecorders.recorders, r)
+}
+
+// Unused returns the number of recorded interactions which haven't been replayed
+func (r *Recorder) Unused() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	unused := 0
+	for key, indexes := range r.interactions {
+		if consumed := r.consumed[key]; consumed < len(indexes) {
+			unused += len(indexes) - consumed
+		}
+	}
+	return unused
+}
+
+type sharedSender struct {
+	inner autorest.Sender
+}
+
+// SharedSender returns a Sender which is intended to be used by the (shared) Test Client, which
+// routes each request to the registered Recorder for the test which has previously requested
+// (or when replaying, recorded) the same URL. Requests which aren't handled by a Recorder are
+// sent using the `inner` Sender, or return an error when replaying.
+func SharedSender(inner autorest.Sender) autorest.Sender {
+	return sharedSender{
+		inner: inner,
+	}
+}
+
+func (s sharedSender) Do(req *http.Request) (*http.Response, error) {
+	var recorder *Recorder
+	activeRecorders.Lock()
+	for r := range activeRecorders.recorders {
+		if r.handles(req) {
+			recorder = r
+			break
+		}
+	}
+	activeRecorders.Unlock()
+
+	if recorder != nil {
+		return recorder.Do(req)
+	}
+
+	if s.inner == nil {
+		return nil, fmt.Errorf("no Cassette contains a recorded interaction for %s %s", req.Method, req.URL.String())
+	}
+
+	log.Printf("[DEBUG] No Recorder is handling %s %s - sending without recording", req.Method, req.URL.String())
+	return s.inner.Do(req)
+}
@@ -0,0 +1,165 @@
+package recording
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	subscriptionId := "12345678-1234-9876-4563-123456789012"
+	t.Setenv(DirectoryEnvVar, t.TempDir())
+	t.Setenv("ARM_SUBSCRIPTION_ID", subscriptionId)
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("http://%s/subscriptions/%s/operations/1", r.Host, subscriptionId))
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": "/subscriptions/%s/resourceGroups/example"}`, subscriptionId)
+		case http.MethodGet:
+			polls++
+			fmt.Fprintf(w, `{"poll": %d}`, polls)
+		}
+	}))
+	defer server.Close()
+
+	resourceGroupUri := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/example?api-version=2020-01-01", server.URL, subscriptionId)
+
+	recorder, err := NewRecorder(&Cassette{Name: t.Name()}, ModeRecord, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	send(t, recorder, http.MethodPut, resourceGroupUri, `{"location": "westeurope"}`)
+	send(t, recorder, http.MethodGet, resourceGroupUri, "")
+	send(t, recorder, http.MethodGet, resourceGroupUri, "")
+	if err := recorder.Cassette().Save(); err != nil {
+		t.Fatalf("saving Cassette: %+v", err)
+	}
+
+	cassette, err := LoadCassette(t.Name())
+	if err != nil {
+		t.Fatalf("loading Cassette: %+v", err)
+	}
+	if len(cassette.Interactions) != 3 {
+		t.Fatalf("expected 3 interactions but got %d", len(cassette.Interactions))
+	}
+	for _, interaction := range cassette.Interactions {
+		if strings.Contains(interaction.Request.URL, subscriptionId) || strings.Contains(interaction.Response.Body, subscriptionId) {
+			t.Fatalf("expected the Subscription ID to be scrubbed from %+v", interaction)
+		}
+	}
+
+	replayer, err := NewRecorder(cassette, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+
+	resp, body := send(t, replayer, http.MethodPut, resourceGroupUri, "")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a %d but got %d", http.StatusCreated, resp.StatusCode)
+	}
+	if v := resp.Header.Get("Retry-After"); v != "0" {
+		t.Fatalf("expected the Retry-After header to be 0 when replaying but got %q", v)
+	}
+
+	// requests are replayed in order, with the last response being repeated
+	for _, expected := range []string{`{"poll": 1}`, `{"poll": 2}`, `{"poll": 2}`} {
+		_, body = send(t, replayer, http.MethodGet, resourceGroupUri, "")
+		if body != expected {
+			t.Fatalf("expected %q but got %q", expected, body)
+		}
+	}
+
+	if _, err := replayer.Do(mustRequest(t, http.MethodDelete, resourceGroupUri, "")); err == nil {
+		t.Fatalf("expected an error for a request which wasn't recorded")
+	}
+}
+
+func TestRequestKey(t *testing.T) {
+	first, _, err := requestKey("get", "https://management.azure.com/Subscriptions/abc/resourceGroups/Example/?b=2&a=1")
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	second, _, err := requestKey("GET", "https://management.azure.com/subscriptions/abc/resourcegroups/example?a=1&b=2")
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	if first != second {
+		t.Fatalf("expected %q and %q to match", first, second)
+	}
+}
+
+func send(t *testing.T, recorder *Recorder, method, uri, body string) (*http.Response, string) {
+	resp, err := recorder.Do(mustRequest(t, method, uri, body))
+	if err != nil {
+		t.Fatalf("sending %s %s: %+v", method, uri, err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %+v", err)
+	}
+
+	return resp, string(contents)
+}
+
+func mustRequest(t *testing.T, method, uri, body string) *http.Request {
+	req, err := http.NewRequest(method, uri, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	return req
+}
+
+func TestRedactBody(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "",
+			expected: "",
+		},
+		{
+			input:    "not json",
+			expected: "not json",
+		},
+		{
+			input:    `{"name": "example", "properties": {"enabled": true}}`,
+			expected: `{"name": "example", "properties": {"enabled": true}}`,
+		},
+		{
+			input:    `{"keys":[{"keyName":"key1","value":"abc123==","permissions":"FULL"}]}`,
+			expected: `{"keys":[{"keyName":"key1","permissions":"FULL","value":"cmVkYWN0ZWQ="}]}`,
+		},
+		{
+			input:    `{"sshPassword":"hunter2"}`,
+			expected: `{"sshPassword":"cmVkYWN0ZWQ="}`,
+		},
+		{
+			input:    `{"sharedKey":"abc","sshAuthorizedKeys":[{"description":"example","key":"ssh-rsa AAAA"}]}`,
+			expected: `{"sharedKey":"cmVkYWN0ZWQ=","sshAuthorizedKeys":[{"description":"example","key":"ssh-rsa AAAA"}]}`,
+		},
+		{
+			input:    `{"primaryKey":"abc","sshPublicKey":"ssh-rsa AAAA","partitionKey":{"paths":["/id"]},"partitionKeyPath":"/id","keyName":"example"}`,
+			expected: `{"keyName":"example","partitionKey":{"paths":["/id"]},"partitionKeyPath":"/id","primaryKey":"cmVkYWN0ZWQ=","sshPublicKey":"ssh-rsa AAAA"}`,
+		},
+		{
+			input:    `{"properties":{"adminPassword":"P@ssw0rd","count":12345678901234567890},"tags":{"secret":"value"}}`,
+			expected: `{"properties":{"adminPassword":"cmVkYWN0ZWQ=","count":12345678901234567890},"tags":{"secret":"value"}}`,
+		},
+	}
+
+	for _, v := range testData {
+		if actual := redactBody(v.input); actual != v.expected {
+			t.Fatalf("expected %q to be redacted to %q but got %q", v.input, v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RedactedValue is written into Cassettes in place of secrets (such as Access Keys and Passwords),
+// this is valid base64 since some secrets (e.g. Storage Account Keys) are decoded when replaying
+const RedactedValue = "cmVkYWN0ZWQ="
+
+// sensitiveFieldSuffixes are the (lower-cased) suffixes of JSON field names whose values are secrets,
+// for example `sshPassword`, `clientSecret` and `primaryConnectionString`
+var sensitiveFieldSuffixes = []string{
+	"password",
+	"secret",
+	"connectionstring",
+}
+
+// sensitiveFields are the (lower-cased) JSON field names containing keys which are secrets - these are listed
+// explicitly since many fields ending in `key` (e.g. `sshPublicKey` or `partitionKey`) are sent from the
+// Terraform Configuration, and need to be replayed as-is to avoid a diff
+var sensitiveFields = map[string]struct{}{
+	"accesskey":                  {},
+	"accountkey":                 {},
+	"authkey1":                   {},
+	"authkey2":                   {},
+	"masterkey":                  {},
+	"primaryaccesskey":           {},
+	"primarykey":                 {},
+	"primarymasterkey":           {},
+	"primaryreadonlymasterkey":   {},
+	"primarysharedkey":           {},
+	"privatekey":                 {},
+	"secondaryaccesskey":         {},
+	"secondarykey":               {},
+	"secondarymasterkey":         {},
+	"secondaryreadonlymasterkey": {},
+	"secondarysharedkey":         {},
+	"sharedkey":                  {},
+	"storageaccountkey":          {},
+}
+
+// redactBody replaces the value of any sensitive fields within a JSON request/response body with
+// RedactedValue - bodies which aren't JSON are returned as-is
+func redactBody(input string) string {
+	if input == "" {
+		return input
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return input
+	}
+
+	if !redactValue(body) {
+		return input
+	}
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(body); err != nil {
+		return input
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactValue redacts any sensitive fields within the specified value in-place, returning
+// whether anything was redacted
+func redactValue(input interface{}) bool {
+	redacted := false
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		// the keys returned from a `listKeys` action are of the form `{"keyName": "key1", "value": "..."}`
+		_, isListedKey := v["keyName"]
+
+		for field, value := range v {
+			// the keys within Tags are user-specified and are replayed as-is
+			if strings.EqualFold(field, "tags") {
+				continue
+			}
+
+			if _, ok := value.(string); ok && (isSensitiveField(field) || (isListedKey && strings.EqualFold(field, "value"))) {
+				v[field] = RedactedValue
+				redacted = true
+				continue
+			}
+
+			if redactValue(value) {
+				redacted = true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if redactValue(item) {
+				redacted = true
+			}
+		}
+	}
+
+	return redacted
+}
+
+func isSensitiveField(field string) bool {
+	field = strings.ToLower(field)
+	if _, ok := sensitiveFields[field]; ok {
+		return true
+	}
+
+	for _, suffix := range sensitiveFieldSuffixes {
+		if strings.HasSuffix(field, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 	"sync"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -26,6 +29,18 @@ func Build() (*clients.Client, error) {
 			environment = "public"
 		}
 
+		// when recording/replaying requests, the requests made by the test client are routed to the
+		// Cassette for the test which is (or was) operating on the same resource
+		mode := recording.CurrentMode()
+		var customSender autorest.Sender
+		switch mode {
+		case recording.ModeRecord:
+			customSender = recording.SharedSender(sender.BuildSender("AzureRM"))
+		case recording.ModeReplay:
+			recording.ConfigureReplayEnvironment()
+			customSender = recording.SharedSender(nil)
+		}
+
 		builder := authentication.Builder{
 			SubscriptionID: os.Getenv("ARM_SUBSCRIPTION_ID"),
 			ClientID:       os.Getenv("ARM_CLIENT_ID"),
@@ -48,6 +63,8 @@ func Build() (*clients.Client, error) {
 			TerraformVersion:         os.Getenv("TERRAFORM_CORE_VERSION"),
 			Features:                 features.Default(),
 			StorageUseAzureAD:        false,
+			CustomSender:             customSender,
+			SkipAuthentication:       mode == recording.ModeReplay,
 		}
 		client, err := clients.Build(context.TODO(), clientBuilder)
 		if err != nil {
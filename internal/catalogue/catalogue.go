@@ -0,0 +1,151 @@
+package catalogue
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// the catalogue for each Azure Environment is generated from the Azure API via `make generate-catalogue`
+// (or `go generate`), see `internal/tools/generator-catalogue` for more information
+//
+//go:generate go run ../tools/generator-catalogue -environment=public -path=./data
+//go:generate go run ../tools/generator-catalogue -environment=usgovernment -path=./data
+//go:generate go run ../tools/generator-catalogue -environment=china -path=./data
+//go:embed data/*.json
+var files embed.FS
+
+// Catalogue is an offline copy of the Resource Providers and Locations available within an Azure Environment
+// - which is embedded into the binary so that Enhanced Validation is possible when these can't be retrieved
+// from the Azure API's.
+//
+// Since this is a snapshot taken when the Provider was released (and Resource Providers and Locations are added
+// to Azure between releases) a value which isn't present in the Catalogue may still be valid - as such the
+// validation using it only warns, unlike the validation using the values retrieved from Azure.
+type Catalogue struct {
+	// Version is the date on which this Catalogue was generated, e.g. `2022-03-01`
+	Version string `json:"version"`
+
+	// Environment is the name of the Azure Environment this Catalogue is for, e.g. `public`
+	Environment string `json:"environment"`
+
+	// ResourceProviders is a list of the Resource Provider Namespaces (e.g. `Microsoft.Compute`)
+	// available within this Azure Environment
+	ResourceProviders []string `json:"resource_providers"`
+
+	// Locations is a list of the (normalized) Locations available within this Azure Environment
+	Locations []string `json:"locations"`
+}
+
+// HasResourceProvider returns whether the specified Resource Provider Namespace exists in this Catalogue,
+// which (as with the Azure API) is case-sensitive
+func (c Catalogue) HasResourceProvider(namespace string) bool {
+	for _, v := range c.ResourceProviders {
+		if v == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLocation returns whether the specified Location exists in this Catalogue, e.g. `West Europe` or `westeurope`
+func (c Catalogue) HasLocation(location string) bool {
+	normalized := normalizeLocation(location)
+	for _, v := range c.Locations {
+		if v == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	cacheLock sync.Mutex
+	cache     = make(map[string]*Catalogue)
+
+	// environment is the name of the Azure Environment in use, which defaults to the `ARM_ENVIRONMENT`
+	// Environment Variable (as with the Provider block) since validation can take place before the
+	// Provider has been configured
+	environment = os.Getenv("ARM_ENVIRONMENT")
+
+	// liveDataAvailable specifies whether the Locations and Resource Providers were retrieved from Azure
+	// when the Provider was configured, in which case these are used for validation rather than the Catalogue
+	liveDataAvailable bool
+)
+
+// SetEnvironment sets the name of the Azure Environment in use (e.g. `public`), which determines
+// the Catalogue returned from Current
+func SetEnvironment(name string) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	environment = name
+}
+
+// SetLiveDataAvailable records whether the Locations and Resource Providers were retrieved from Azure
+// when the Provider was configured
+func SetLiveDataAvailable(available bool) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	liveDataAvailable = available
+}
+
+// LiveDataAvailable returns whether the Locations and Resource Providers were retrieved from Azure
+// when the Provider was configured - and as such whether the Catalogue should be used for validation
+func LiveDataAvailable() bool {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	return liveDataAvailable
+}
+
+// Current returns the Catalogue for the Azure Environment in use, or nil if there isn't a Catalogue
+// available for this Azure Environment (for example when using a custom Metadata Host)
+func Current() *Catalogue {
+	cacheLock.Lock()
+	name := environment
+	cacheLock.Unlock()
+
+	if name == "" {
+		name = "public"
+	}
+
+	catalogue, err := ForEnvironment(name)
+	if err != nil {
+		return nil
+	}
+	return catalogue
+}
+
+// ForEnvironment returns the Catalogue for the specified Azure Environment, e.g. `public`, `usgovernment` or `china`
+func ForEnvironment(name string) (*Catalogue, error) {
+	name = strings.ToLower(name)
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if existing, ok := cache[name]; ok {
+		return existing, nil
+	}
+
+	contents, err := files.ReadFile(fmt.Sprintf("data/%s.json", name))
+	if err != nil {
+		return nil, fmt.Errorf("a Catalogue isn't available for the Azure Environment %q", name)
+	}
+
+	var catalogue Catalogue
+	if err := json.Unmarshal(contents, &catalogue); err != nil {
+		return nil, fmt.Errorf("unmarshalling the Catalogue for the Azure Environment %q: %+v", name, err)
+	}
+
+	cache[name] = &catalogue
+	return &catalogue, nil
+}
+
+func normalizeLocation(input string) string {
+	return strings.ReplaceAll(strings.ToLower(input), " ", "")
+}
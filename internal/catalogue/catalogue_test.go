@@ -0,0 +1,75 @@
+package catalogue
+
+import (
+	"testing"
+)
+
+func TestCatalogueEnvironments(t *testing.T) {
+	for _, environment := range []string{"public", "usgovernment", "china"} {
+		t.Logf("[DEBUG] Testing %q..", environment)
+
+		c, err := ForEnvironment(environment)
+		if err != nil {
+			t.Fatalf("loading the catalogue: %+v", err)
+		}
+		if c.Environment != environment {
+			t.Fatalf("expected the environment to be %q but got %q", environment, c.Environment)
+		}
+		if c.Version == "" {
+			t.Fatalf("expected a version")
+		}
+		if !c.HasResourceProvider("Microsoft.Resources") {
+			t.Fatalf("expected `Microsoft.Resources` to be available")
+		}
+		if len(c.Locations) == 0 {
+			t.Fatalf("expected some locations")
+		}
+	}
+
+	if _, err := ForEnvironment("german"); err == nil {
+		t.Fatalf("expected an error for an environment without a catalogue")
+	}
+}
+
+func TestCatalogueLookups(t *testing.T) {
+	c, err := ForEnvironment("public")
+	if err != nil {
+		t.Fatalf("loading the catalogue: %+v", err)
+	}
+
+	if !c.HasLocation("West Europe") || !c.HasLocation("westeurope") {
+		t.Fatalf("expected `West Europe` to be available")
+	}
+	if c.HasLocation("West Eurpoe") {
+		t.Fatalf("expected `West Eurpoe` not to be available")
+	}
+	if c.HasLocation("usgovvirginia") {
+		t.Fatalf("expected `usgovvirginia` not to be available in the public cloud")
+	}
+
+	if c.HasResourceProvider("Microsoft.Comptue") {
+		t.Fatalf("expected `Microsoft.Comptue` not to be available")
+	}
+	if c.HasResourceProvider("microsoft.compute") {
+		t.Fatalf("expected the Resource Provider lookup to be case-sensitive")
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	defer SetEnvironment("")
+
+	SetEnvironment("china")
+	if c := Current(); c == nil || c.Environment != "china" {
+		t.Fatalf("expected the china catalogue but got %+v", c)
+	}
+
+	SetEnvironment("")
+	if c := Current(); c == nil || c.Environment != "public" {
+		t.Fatalf("expected the public catalogue by default but got %+v", c)
+	}
+
+	SetEnvironment("german")
+	if c := Current(); c != nil {
+		t.Fatalf("expected no catalogue for an environment without one")
+	}
+}
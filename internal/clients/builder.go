@@ -7,12 +7,16 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/catalogue"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/telemetry"
 	"github.com/manicminer/hamilton/environments"
 )
 
@@ -26,6 +30,23 @@ type ClientBuilder struct {
 	StorageUseAzureAD           bool
 	TerraformVersion            string
 	Features                    features.UserFeatures
+	DefaultTags                 map[string]string
+	IgnoreTags                  tags.IgnoreConfiguration
+	TagPolicy                   tags.Policy
+	Retry                       common.RetryOptions
+	RateLimit                   common.RateLimitOptions
+	ReadCache                   common.ReadCacheOptions
+
+	// TelemetrySummaryFile (when specified) is the path to a file where a summary of the requests sent to
+	// Azure Resource Manager during this run is written
+	TelemetrySummaryFile string
+
+	// CustomSender (when specified) is used in place of the default Sender for the Resource Manager clients
+	CustomSender autorest.Sender
+
+	// SkipAuthentication skips obtaining tokens from Azure Active Directory, using a NullAuthorizer instead,
+	// this is only intended for use when replaying recorded requests in the Acceptance Tests
+	SkipAuthentication bool
 }
 
 const azureStackEnvironmentError = `
@@ -57,21 +78,31 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("unable to find environment %q from endpoint %q: %+v", builder.AuthConfig.Environment, builder.AuthConfig.MetadataHost, err)
 	}
 
+	// a custom Resource Manager endpoint can be used, for example to point at a fake Resource Manager in tests
+	if builder.AuthConfig.CustomResourceManagerEndpoint != "" {
+		env.ResourceManagerEndpoint = builder.AuthConfig.CustomResourceManagerEndpoint
+	}
+
 	// Hamilton environment configuration
 	environment, err := environments.EnvironmentFromString(builder.AuthConfig.Environment)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find environment %q from endpoint %q: %+v", builder.AuthConfig.Environment, builder.AuthConfig.MetadataHost, err)
 	}
 
+	// the offline catalogue for this Azure Environment is used for Enhanced Validation when the Azure API's are unavailable
+	catalogue.SetEnvironment(builder.AuthConfig.Environment)
+
+	if builder.SkipAuthentication {
+		return buildWithoutAuthentication(ctx, builder, *env)
+	}
+
 	// client declarations:
 	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, *env, builder.SkipProviderRegistration)
 	if err != nil {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
 
-	client := Client{
-		Account: account,
-	}
+	client := newClient(builder, account)
 
 	oauthConfig, err := builder.AuthConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
 	if err != nil {
@@ -125,35 +156,107 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return authorizer, nil
 	}
 
-	o := &common.ClientOptions{
+	o := buildClientOptions(builder, *env, clientAuthorizers{
+		ResourceManager: auth,
+		Storage:         storageAuth,
+		Synapse:         synapseAuth,
+		BatchManagement: batchManagementAuth,
+		KeyVault:        keyVaultAuth,
+		TokenFunc:       tokenFunc,
+	})
+
+	if err := client.Build(ctx, o); err != nil {
+		return nil, fmt.Errorf("building Client: %+v", err)
+	}
+
+	if features.EnhancedValidationEnabled() {
+		location.CacheSupportedLocations(ctx, env.ResourceManagerEndpoint)
+
+		// the Locations and Resource Providers are both retrieved from Resource Manager, so when these can't be
+		// retrieved validation falls back to the offline catalogue
+		catalogue.SetLiveDataAvailable(resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient))
+	}
+
+	return &client, nil
+}
+
+// buildWithoutAuthentication builds a Client which uses a NullAuthorizer for each API, which is used
+// when replaying recorded requests - and as such no requests are made to Azure Active Directory
+func buildWithoutAuthentication(ctx context.Context, builder ClientBuilder, env azure.Environment) (*Client, error) {
+	client := newClient(builder, &ResourceManagerAccount{
+		AuthenticatedAsAServicePrincipal: builder.AuthConfig.AuthenticatedAsAServicePrincipal,
+		ClientId:                         builder.AuthConfig.ClientID,
+		Environment:                      env,
+		SkipResourceProviderRegistration: builder.SkipProviderRegistration,
+		SubscriptionId:                   builder.AuthConfig.SubscriptionID,
+		TenantId:                         builder.AuthConfig.TenantID,
+	})
+
+	o := buildClientOptions(builder, env, clientAuthorizers{
+		ResourceManager: autorest.NullAuthorizer{},
+		Storage:         autorest.NullAuthorizer{},
+		Synapse:         autorest.NullAuthorizer{},
+		BatchManagement: autorest.NullAuthorizer{},
+		KeyVault:        autorest.NullAuthorizer{},
+		TokenFunc: func(endpoint string) (autorest.Authorizer, error) {
+			return autorest.NullAuthorizer{}, nil
+		},
+	})
+
+	if err := client.Build(ctx, o); err != nil {
+		return nil, fmt.Errorf("building Client: %+v", err)
+	}
+
+	return &client, nil
+}
+
+// clientAuthorizers are the Authorizers used by the clients for each API
+type clientAuthorizers struct {
+	ResourceManager autorest.Authorizer
+	Storage         autorest.Authorizer
+	Synapse         autorest.Authorizer
+	BatchManagement autorest.Authorizer
+	KeyVault        autorest.Authorizer
+	TokenFunc       common.EndpointTokenFunc
+}
+
+// newClient returns the (unbuilt) Client for the specified Account, configured from the ClientBuilder
+func newClient(builder ClientBuilder, account *ResourceManagerAccount) Client {
+	return Client{
+		Account:         account,
+		DefaultTags:     builder.DefaultTags,
+		IgnoreTags:      builder.IgnoreTags,
+		TagPolicy:       builder.TagPolicy,
+		TagPolicyReport: tags.NewPolicyReport(),
+		Telemetry:       telemetry.NewRecorder(builder.TelemetrySummaryFile),
+	}
+}
+
+// buildClientOptions returns the ClientOptions used to configure each of the clients, which
+// is shared between an authenticated and an unauthenticated Client
+func buildClientOptions(builder ClientBuilder, env azure.Environment, authorizers clientAuthorizers) *common.ClientOptions {
+	return &common.ClientOptions{
 		SubscriptionId:              builder.AuthConfig.SubscriptionID,
 		TenantID:                    builder.AuthConfig.TenantID,
 		PartnerId:                   builder.PartnerId,
 		TerraformVersion:            builder.TerraformVersion,
-		KeyVaultAuthorizer:          keyVaultAuth,
-		ResourceManagerAuthorizer:   auth,
+		KeyVaultAuthorizer:          authorizers.KeyVault,
+		ResourceManagerAuthorizer:   authorizers.ResourceManager,
 		ResourceManagerEndpoint:     env.ResourceManagerEndpoint,
-		StorageAuthorizer:           storageAuth,
-		SynapseAuthorizer:           synapseAuth,
-		BatchManagementAuthorizer:   batchManagementAuth,
+		StorageAuthorizer:           authorizers.Storage,
+		SynapseAuthorizer:           authorizers.Synapse,
+		BatchManagementAuthorizer:   authorizers.BatchManagement,
 		SkipProviderReg:             builder.SkipProviderRegistration,
 		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
 		CustomCorrelationRequestID:  builder.CustomCorrelationRequestID,
 		DisableTerraformPartnerID:   builder.DisableTerraformPartnerID,
-		Environment:                 *env,
+		Environment:                 env,
 		Features:                    builder.Features,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
-		TokenFunc:                   tokenFunc,
-	}
-
-	if err := client.Build(ctx, o); err != nil {
-		return nil, fmt.Errorf("building Client: %+v", err)
+		TokenFunc:                   authorizers.TokenFunc,
+		CustomSender:                builder.CustomSender,
+		Retry:                       builder.Retry,
+		RateLimiter:                 common.NewRateLimiter(env.ResourceManagerEndpoint, builder.RateLimit),
+		ReadCache:                   common.NewReadCache(env.ResourceManagerEndpoint, builder.ReadCache),
 	}
-
-	if features.EnhancedValidationEnabled() {
-		location.CacheSupportedLocations(ctx, env.ResourceManagerEndpoint)
-		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient)
-	}
-
-	return &client, nil
 }
@@ -108,6 +108,8 @@ import (
 	videoAnalyzer "github.com/hashicorp/terraform-provider-azurerm/internal/services/videoanalyzer/client"
 	vmware "github.com/hashicorp/terraform-provider-azurerm/internal/services/vmware/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/telemetry"
 )
 
 type Client struct {
@@ -117,6 +119,25 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// DefaultTags contains the Tags which should be applied to every Resource supporting Tags
+	DefaultTags map[string]string
+
+	// IgnoreTags contains the Tags which are managed outside of Terraform and should be ignored
+	IgnoreTags tags.IgnoreConfiguration
+
+	// TagPolicy contains the organisational rules which the Tags for each Resource must meet
+	TagPolicy tags.Policy
+
+	// TagPolicyReport collects the resources planned during this run which don't meet the TagPolicy
+	TagPolicyReport *tags.PolicyReport
+
+	// CorrelationRequestID is the value sent in the `x-ms-correlation-request-id` header for each
+	// request to Azure, which is empty when sending the Correlation Request ID is disabled
+	CorrelationRequestID string
+
+	// Telemetry aggregates the requests sent to Azure Resource Manager for each operation during this run
+	Telemetry *telemetry.Recorder
+
 	AadB2c                *aadb2c.Client
 	Advisor               *advisor.Client
 	AnalysisServices      *analysisServices.Client
@@ -218,6 +239,12 @@ type Client struct {
 	VideoAnalyzer         *videoAnalyzer.Client
 	Vmware                *vmware.Client
 	Web                   *web.Client
+
+	// options are the ClientOptions this Client was built from, used to build Clients for other Subscriptions
+	options *common.ClientOptions
+
+	// subscriptionClients caches the Clients for other Subscriptions, see ForSubscription
+	subscriptionClients *subscriptionClients
 }
 
 // NOTE: it should be possible for this method to become Private once the top level Client's removed
@@ -229,6 +256,12 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	client.Features = o.Features
 	client.StopContext = ctx
+	client.CorrelationRequestID = o.CorrelationRequestID()
+
+	client.options = o
+	if client.subscriptionClients == nil && client.Account != nil {
+		client.subscriptionClients = newSubscriptionClients(client)
+	}
 
 	client.AadB2c = aadb2c.NewClient(o)
 	client.Advisor = advisor.NewClient(o)
@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// subscriptionClients caches the Clients built for Subscriptions other than the one the Provider is
+// configured for, which is shared between the root Client and each of the Clients it's built
+type subscriptionClients struct {
+	lock    sync.Mutex
+	clients map[string]*Client
+
+	// stopContext is the StopContext of the root Client, which the cached Clients are built from since these
+	// outlive the operation they're first requested during
+	stopContext context.Context
+}
+
+// ForSubscription returns a Client whose service clients target the specified Subscription, such that a
+// Resource can be managed within the Subscription encoded in its Resource ID without an aliased Provider.
+//
+// The Client is built the first time it's requested and cached for the remainder of the run - the same
+// credentials, features, retry and rate limiting configuration are used as for the root Client. When the
+// Subscription ID is empty or matches the configured Subscription, this Client is returned.
+func (client *Client) ForSubscription(subscriptionId string) (*Client, error) {
+	if subscriptionId == "" || client.Account == nil || strings.EqualFold(subscriptionId, client.Account.SubscriptionId) {
+		return client, nil
+	}
+
+	if client.options == nil || client.subscriptionClients == nil {
+		return nil, fmt.Errorf("building a client for Subscription %q: the client has not been built", subscriptionId)
+	}
+
+	cache := client.subscriptionClients
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	key := strings.ToLower(subscriptionId)
+	if existing, ok := cache.clients[key]; ok {
+		return existing, nil
+	}
+
+	account := *client.Account
+	account.SubscriptionId = subscriptionId
+
+	options := *client.options
+	options.SubscriptionId = subscriptionId
+
+	sub := &Client{
+		Account:             &account,
+		DefaultTags:         client.DefaultTags,
+		IgnoreTags:          client.IgnoreTags,
+		TagPolicy:           client.TagPolicy,
+		TagPolicyReport:     client.TagPolicyReport,
+		Telemetry:           client.Telemetry,
+		subscriptionClients: cache,
+	}
+	if err := sub.Build(cache.stopContext, &options); err != nil {
+		return nil, fmt.Errorf("building a client for Subscription %q: %+v", subscriptionId, err)
+	}
+
+	cache.clients[key] = sub
+	return sub, nil
+}
+
+// newSubscriptionClients returns the cache of per-Subscription Clients, seeded with the root Client
+func newSubscriptionClients(root *Client) *subscriptionClients {
+	return &subscriptionClients{
+		clients: map[string]*Client{
+			strings.ToLower(root.Account.SubscriptionId): root,
+		},
+		stopContext: root.StopContext,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/telemetry"
 	"github.com/hashicorp/terraform-provider-azurerm/version"
 )
 
@@ -39,6 +40,21 @@ type ClientOptions struct {
 	// Some Dataplane APIs require a token scoped for a specific endpoint
 	TokenFunc EndpointTokenFunc
 
+	// CustomSender (when specified) is used in place of the default Sender, which allows
+	// the Acceptance Tests to record and replay the requests sent to Resource Manager
+	CustomSender autorest.Sender
+
+	// Retry configures how requests are retried when Azure is throttling requests or returns a transient error
+	Retry RetryOptions
+
+	// RateLimiter (when specified) limits the rate at which requests are sent to Resource Manager, and is
+	// shared by every client configured using these options
+	RateLimiter *RateLimiter
+
+	// ReadCache (when specified) caches the responses to GET requests sent to Resource Manager for the
+	// remainder of the run, and is shared by every client configured using these options
+	ReadCache *ReadCache
+
 	// TODO: remove graph configuration in v3.0
 	GraphAuthorizer autorest.Authorizer
 	GraphEndpoint   string
@@ -49,16 +65,45 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 
 	c.Authorizer = authorizer
 	c.Sender = sender.BuildSender("AzureRM")
+	if o.CustomSender != nil {
+		c.Sender = o.CustomSender
+	}
+	// records the telemetry for each request against the resource being operated on, see `telemetry.WithScope`
+	c.Sender = telemetry.Sender(c.Sender)
+	c.Sender = o.RateLimiter.Sender(c.Sender)
+	// each attempt is recorded in the telemetry (and rate limited), so retries are applied outside of it
+	c.Sender = withRetries(c.Sender, o.Retry)
+	// cached responses are returned without being sent (and as such without being rate limited or retried)
+	c.Sender = o.ReadCache.Sender(c.Sender)
+	if o.Retry.Configured() {
+		// the Send Decorators passed to `client.Send` by the generated SDKs retry 429 and 5xx responses (sleeping
+		// between each attempt) - which are replaced when SendDecorators is non-nil, so that there's a single retry
+		// loop. RetryAttempts can't be used for this, since `azure.DoRetryWithRegistration` doesn't send the request
+		// when it's zero. Note that the Storage Data Plane SDK passes these decorators to `autorest.SendWithSender`
+		// directly - and as such requests sent by it are also retried by autorest.
+		c.SendDecorators = []autorest.SendDecorator{}
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
-	if !o.DisableCorrelationRequestID {
-		id := o.CustomCorrelationRequestID
-		if id == "" {
-			id = correlationRequestID()
-		}
+	if id := o.CorrelationRequestID(); id != "" {
 		c.RequestInspector = withCorrelationRequestID(id)
 	}
 }
 
+// CorrelationRequestID returns the value sent in the `x-ms-correlation-request-id` header for each
+// request to Azure, which is either the user-specified value or one generated for this run of the
+// Provider - or an empty string when sending the Correlation Request ID is disabled
+func (o ClientOptions) CorrelationRequestID() string {
+	if o.DisableCorrelationRequestID {
+		return ""
+	}
+
+	if o.CustomCorrelationRequestID != "" {
+		return o.CustomCorrelationRequestID
+	}
+
+	return correlationRequestID()
+}
+
 func setUserAgent(client *autorest.Client, tfVersion, partnerID string, disableTerraformPartnerID bool) {
 	tfUserAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", tfVersion, meta.SDKVersionString())
 
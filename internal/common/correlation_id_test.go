@@ -29,3 +29,17 @@ func TestWithCorrelationRequestID(t *testing.T) {
 			HeaderCorrelationRequestID, uuid, req.Header.Get(HeaderCorrelationRequestID))
 	}
 }
+
+func TestClientOptionsCorrelationRequestID(t *testing.T) {
+	if id := (ClientOptions{}).CorrelationRequestID(); id != correlationRequestID() {
+		t.Fatalf("expected the generated correlation request ID %q but got %q", correlationRequestID(), id)
+	}
+
+	if id := (ClientOptions{CustomCorrelationRequestID: "custom"}).CorrelationRequestID(); id != "custom" {
+		t.Fatalf("expected the custom correlation request ID but got %q", id)
+	}
+
+	if id := (ClientOptions{DisableCorrelationRequestID: true, CustomCorrelationRequestID: "custom"}).CorrelationRequestID(); id != "" {
+		t.Fatalf("expected no correlation request ID when disabled but got %q", id)
+	}
+}
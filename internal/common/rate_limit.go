@@ -0,0 +1,265 @@
+package common
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// rateLimitSlowdownThreshold is the remaining quota (from the `x-ms-ratelimit-remaining-*` headers) below
+	// which requests are slowed down, in proportion to the remaining quota
+	rateLimitSlowdownThreshold = 100
+
+	// rateLimitMinimumFactor is the smallest fraction of the configured rate which requests are slowed down to
+	rateLimitMinimumFactor = 0.1
+)
+
+// RateLimitOptions configures the client-side rate limiting of requests sent to Azure Resource Manager - the
+// zero value disables rate limiting.
+type RateLimitOptions struct {
+	// Enabled specifies whether requests to Azure Resource Manager are rate limited
+	Enabled bool
+
+	// ReadsPerSecond is the sustained rate at which read (GET/HEAD) requests are sent for each Subscription
+	ReadsPerSecond float64
+
+	// WritesPerSecond is the sustained rate at which write (PUT/PATCH/POST/DELETE) requests are sent for each Subscription
+	WritesPerSecond float64
+
+	// Burst is the number of requests of each kind which can be sent at once before the rate applies
+	Burst int
+
+	// Adaptive specifies whether requests are slowed down as the remaining quota reported by Azure
+	// Resource Manager (in the `x-ms-ratelimit-remaining-*` headers) runs low
+	Adaptive bool
+}
+
+// RateLimiter limits the rate at which requests are sent to Azure Resource Manager, using a token bucket for
+// the reads and writes to each Subscription (or to the Tenant, for requests outside of a Subscription) - which
+// is shared by every client built from the same ClientOptions.
+type RateLimiter struct {
+	options RateLimitOptions
+	host    string
+
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiter returns a RateLimiter for requests sent to the specified Resource Manager endpoint, or nil
+// when rate limiting is disabled
+func NewRateLimiter(resourceManagerEndpoint string, options RateLimitOptions) *RateLimiter {
+	if !options.Enabled {
+		return nil
+	}
+
+	host := resourceManagerEndpoint
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return &RateLimiter{
+		options: options,
+		host:    strings.ToLower(host),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Sender returns a Sender which waits for the rate limit prior to sending each request to Azure Resource
+// Manager via the specified Sender, requests to other endpoints (e.g. Data Plane API's) aren't rate limited
+func (l *RateLimiter) Sender(sender autorest.Sender) autorest.Sender {
+	if l == nil {
+		return sender
+	}
+
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL == nil || !strings.EqualFold(req.URL.Host, l.host) {
+			return sender.Do(req)
+		}
+
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		scope, kind := rateLimitScope(req)
+		bucket := l.bucket(scope, kind)
+		if delay := bucket.reserve(time.Now()); delay > 0 {
+			log.Printf("[DEBUG] Rate limiting %s %s for %s", req.Method, req.URL.Path, delay)
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				// the request isn't sent, so the token is returned for the requests queued behind it
+				timer.Stop()
+				bucket.release()
+				return nil, req.Context().Err()
+			}
+		}
+
+		resp, err := sender.Do(req)
+		if resp != nil && l.options.Adaptive {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				bucket.throttled()
+			} else if remaining, ok := rateLimitRemaining(resp, scope, req.Method); ok {
+				bucket.observe(remaining)
+			}
+		}
+		return resp, err
+	})
+}
+
+func (l *RateLimiter) bucket(scope, kind string) *tokenBucket {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := scope + "|" + kind
+	if bucket, ok := l.buckets[key]; ok {
+		return bucket
+	}
+
+	rate := l.options.ReadsPerSecond
+	if kind == "writes" {
+		rate = l.options.WritesPerSecond
+	}
+	bucket := newTokenBucket(rate, l.options.Burst, time.Now())
+	l.buckets[key] = bucket
+	return bucket
+}
+
+// rateLimitScope returns the scope which the request counts against (the Subscription ID, or `tenant`) and
+// whether it's a read or a write
+func rateLimitScope(req *http.Request) (string, string) {
+	kind := "writes"
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		kind = "reads"
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) >= 2 && strings.EqualFold(segments[0], "subscriptions") && segments[1] != "" {
+		return strings.ToLower(segments[1]), kind
+	}
+	return "tenant", kind
+}
+
+// rateLimitRemaining returns the remaining quota for the request from the response headers, if present
+func rateLimitRemaining(resp *http.Response, scope, method string) (int, bool) {
+	level := "subscription"
+	if scope == "tenant" {
+		level = "tenant"
+	}
+
+	kind := "writes"
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		kind = "reads"
+	case http.MethodDelete:
+		kind = "deletes"
+	}
+
+	v := resp.Header.Get("x-ms-ratelimit-remaining-" + level + "-" + kind)
+	if v == "" && kind == "deletes" {
+		v = resp.Header.Get("x-ms-ratelimit-remaining-" + level + "-writes")
+	}
+	if v == "" {
+		return 0, false
+	}
+
+	remaining, err := strconv.Atoi(v)
+	if err != nil || remaining < 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// tokenBucket allows `burst` requests to be sent at once, refilling at `rate` tokens per second - which is
+// scaled by `factor` when slowed down due to the remaining quota running low
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	factor float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		factor: 1,
+		last:   now,
+	}
+}
+
+// reserve takes a token from the bucket, returning how long to wait before the request can be sent
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	rate := b.rate * b.factor
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	// the token is taken regardless, such that concurrent requests queue behind one another
+	b.tokens--
+	if b.tokens >= 0 || rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
+
+// release returns a token taken by reserve for a request which wasn't sent
+func (b *tokenBucket) release() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// observe adjusts the rate based on the remaining quota reported by Azure Resource Manager
+func (b *tokenBucket) observe(remaining int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	factor := 1.0
+	if remaining < rateLimitSlowdownThreshold {
+		factor = float64(remaining) / rateLimitSlowdownThreshold
+		if factor < rateLimitMinimumFactor {
+			factor = rateLimitMinimumFactor
+		}
+	}
+
+	if factor < 1 && b.factor == 1 {
+		log.Printf("[DEBUG] Slowing down requests since the remaining quota (%d) is running low", remaining)
+	}
+	b.factor = factor
+}
+
+// throttled slows the rate down to the minimum, since Azure Resource Manager is already throttling requests
+func (b *tokenBucket) throttled() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.factor = rateLimitMinimumFactor
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
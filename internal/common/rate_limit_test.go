@@ -0,0 +1,199 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2, now)
+
+	// the burst can be sent immediately
+	for i := 0; i < 2; i++ {
+		if delay := bucket.reserve(now); delay != 0 {
+			t.Fatalf("expected request %d to be sent immediately but got a delay of %s", i+1, delay)
+		}
+	}
+
+	// subsequent requests queue behind one another at the configured rate
+	if delay := bucket.reserve(now); delay != 500*time.Millisecond {
+		t.Fatalf("expected a delay of 500ms but got %s", delay)
+	}
+	if delay := bucket.reserve(now); delay != time.Second {
+		t.Fatalf("expected a delay of 1s but got %s", delay)
+	}
+
+	// a request which isn't sent returns its token to the requests queued behind it
+	bucket.release()
+	if delay := bucket.reserve(now); delay != time.Second {
+		t.Fatalf("expected a delay of 1s once the token was returned but got %s", delay)
+	}
+
+	// once the bucket has refilled, requests are sent immediately again
+	now = now.Add(10 * time.Second)
+	if delay := bucket.reserve(now); delay != 0 {
+		t.Fatalf("expected the request to be sent immediately but got a delay of %s", delay)
+	}
+}
+
+func TestTokenBucketAdaptive(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, 1, now)
+
+	bucket.observe(rateLimitSlowdownThreshold * 2)
+	if bucket.factor != 1 {
+		t.Fatalf("expected the full rate when the remaining quota is high but got a factor of %f", bucket.factor)
+	}
+
+	bucket.observe(rateLimitSlowdownThreshold / 2)
+	if bucket.factor != 0.5 {
+		t.Fatalf("expected half the rate when half the threshold remains but got a factor of %f", bucket.factor)
+	}
+
+	bucket.reserve(now)
+	if delay := bucket.reserve(now); delay != 200*time.Millisecond {
+		t.Fatalf("expected a delay of 200ms at half the rate but got %s", delay)
+	}
+
+	bucket.observe(0)
+	if bucket.factor != rateLimitMinimumFactor {
+		t.Fatalf("expected the minimum rate when no quota remains but got a factor of %f", bucket.factor)
+	}
+
+	bucket.observe(rateLimitSlowdownThreshold)
+	bucket.throttled()
+	if bucket.factor != rateLimitMinimumFactor || bucket.tokens > 0 {
+		t.Fatalf("expected the minimum rate with an empty bucket once throttled but got a factor of %f with %f tokens", bucket.factor, bucket.tokens)
+	}
+}
+
+func TestRateLimitScope(t *testing.T) {
+	testData := []struct {
+		method        string
+		path          string
+		expectedScope string
+		expectedKind  string
+	}{
+		{
+			method:        http.MethodGet,
+			path:          "/subscriptions/ABC-123/resourceGroups/example",
+			expectedScope: "abc-123",
+			expectedKind:  "reads",
+		},
+		{
+			method:        http.MethodDelete,
+			path:          "/subscriptions/abc-123/resourceGroups/example",
+			expectedScope: "abc-123",
+			expectedKind:  "writes",
+		},
+		{
+			method:        http.MethodPut,
+			path:          "/providers/Microsoft.Management/managementGroups/example",
+			expectedScope: "tenant",
+			expectedKind:  "writes",
+		},
+	}
+
+	for _, v := range testData {
+		req, _ := http.NewRequest(v.method, "https://management.azure.com"+v.path, nil)
+		scope, kind := rateLimitScope(req)
+		if scope != v.expectedScope || kind != v.expectedKind {
+			t.Fatalf("expected %q / %q for %s %s but got %q / %q", v.expectedScope, v.expectedKind, v.method, v.path, scope, kind)
+		}
+	}
+}
+
+func TestRateLimitRemaining(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := rateLimitRemaining(resp, "abc-123", http.MethodGet); ok {
+		t.Fatalf("expected no remaining quota when the header is omitted")
+	}
+
+	resp.Header.Set("x-ms-ratelimit-remaining-subscription-reads", "11999")
+	if v, ok := rateLimitRemaining(resp, "abc-123", http.MethodGet); !ok || v != 11999 {
+		t.Fatalf("expected 11999 remaining reads but got %d", v)
+	}
+
+	resp.Header.Set("x-ms-ratelimit-remaining-subscription-writes", "42")
+	if v, ok := rateLimitRemaining(resp, "abc-123", http.MethodDelete); !ok || v != 42 {
+		t.Fatalf("expected the remaining writes to be used for a delete when the deletes header is omitted but got %d", v)
+	}
+
+	resp.Header.Set("x-ms-ratelimit-remaining-tenant-writes", "7")
+	if v, ok := rateLimitRemaining(resp, "tenant", http.MethodPost); !ok || v != 7 {
+		t.Fatalf("expected 7 remaining tenant writes but got %d", v)
+	}
+}
+
+func TestRateLimiterSender(t *testing.T) {
+	if limiter := NewRateLimiter("https://management.azure.com/", RateLimitOptions{}); limiter != nil {
+		t.Fatalf("expected no rate limiter when rate limiting is disabled")
+	}
+
+	limiter := NewRateLimiter("https://management.azure.com/", RateLimitOptions{
+		Enabled:         true,
+		ReadsPerSecond:  1,
+		WritesPerSecond: 1,
+		Burst:           1,
+		Adaptive:        true,
+	})
+
+	sent := 0
+	sender := limiter.Sender(autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+		resp.Header.Set("x-ms-ratelimit-remaining-subscription-reads", "0")
+		return resp, nil
+	}))
+
+	// requests to other endpoints aren't rate limited
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.vault.azure.net/secrets/example", nil)
+		if _, err := sender.Do(req); err != nil {
+			t.Fatalf("sending the request: %+v", err)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/abc-123/resourceGroups/example", nil)
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("sending the request: %+v", err)
+	}
+	if bucket := limiter.bucket("abc-123", "reads"); bucket.factor != rateLimitMinimumFactor {
+		t.Fatalf("expected the reads to be slowed down once the quota is exhausted but got a factor of %f", bucket.factor)
+	}
+
+	// the next request must wait for the bucket to refill, which is cancelled by the context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/subscriptions/abc-123/resourceGroups/example", nil)
+	if _, err := sender.Do(req); err != context.DeadlineExceeded {
+		t.Fatalf("expected the request to be rate limited until the context was cancelled but got %+v", err)
+	}
+	if bucket := limiter.bucket("abc-123", "reads"); bucket.tokens <= -1 {
+		t.Fatalf("expected the token to be returned when the context was cancelled but got %f tokens", bucket.tokens)
+	}
+
+	// a request whose context has already been cancelled doesn't take a token
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/subscriptions/abc-123/resourceGroups/example", nil)
+	if _, err := sender.Do(req); err != context.DeadlineExceeded {
+		t.Fatalf("expected the request to fail since the context was cancelled but got %+v", err)
+	}
+	if bucket := limiter.bucket("abc-123", "reads"); bucket.tokens <= -1 {
+		t.Fatalf("expected no token to be taken when the context was cancelled but got %f tokens", bucket.tokens)
+	}
+
+	if sent != 4 {
+		t.Fatalf("expected 4 requests to be sent but got %d", sent)
+	}
+}
@@ -0,0 +1,312 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// readCacheMaxBodySize is the maximum size of a response body which is cached
+	readCacheMaxBodySize = 4 * 1024 * 1024
+)
+
+// ReadCacheOptions configures the caching of GET requests sent to Azure Resource Manager - the zero value
+// disables caching.
+type ReadCacheOptions struct {
+	// Enabled specifies whether the responses to GET requests are cached for the remainder of the run
+	Enabled bool
+
+	// MaxEntries is the maximum number of responses which are cached, once reached further responses aren't
+	// cached - a value of zero means no limit
+	MaxEntries int
+}
+
+// ReadCache caches the responses to GET requests sent to Azure Resource Manager, keyed by the Resource ID
+// (and query string, which includes the API Version) - which is shared by every client built from the same
+// ClientOptions, such that parent resources (e.g. Virtual Networks or Key Vaults) retrieved by many child
+// resources during a refresh are only retrieved once.
+//
+// A PUT, PATCH or DELETE request invalidates the cached responses for that Resource ID, its parent resource
+// (and anything nested within either) - as does a POST request for the resource the action is performed on,
+// unless the action only retrieves data (e.g. `/listKeys`) - and these are no longer cached for the remainder
+// of the run, since a resource being modified is typically polled until it reaches the expected state.
+type ReadCache struct {
+	options ReadCacheOptions
+	host    string
+
+	lock    sync.Mutex
+	entries map[string]*readCacheEntry
+
+	// modified contains the (lower-cased) Resource IDs which have been modified during this run - and
+	// modifiedCollections the collections containing them
+	modified            map[string]struct{}
+	modifiedCollections map[string]struct{}
+}
+
+type readCacheEntry struct {
+	resourceId string
+	statusCode int
+	status     string
+	proto      string
+	header     http.Header
+	body       []byte
+}
+
+// NewReadCache returns a ReadCache for requests sent to the specified Resource Manager endpoint, or nil
+// when caching is disabled
+func NewReadCache(resourceManagerEndpoint string, options ReadCacheOptions) *ReadCache {
+	if !options.Enabled {
+		return nil
+	}
+
+	host := resourceManagerEndpoint
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return &ReadCache{
+		options:             options,
+		host:                strings.ToLower(host),
+		entries:             make(map[string]*readCacheEntry),
+		modified:            make(map[string]struct{}),
+		modifiedCollections: make(map[string]struct{}),
+	}
+}
+
+// Sender returns a Sender which returns the cached response for GET requests sent to Azure Resource Manager
+// when available (and otherwise caches the response) - requests to other endpoints (e.g. Data Plane API's)
+// aren't cached.
+func (c *ReadCache) Sender(sender autorest.Sender) autorest.Sender {
+	if c == nil {
+		return sender
+	}
+
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL == nil || !strings.EqualFold(req.URL.Host, c.host) {
+			return sender.Do(req)
+		}
+
+		resourceId := readCacheResourceId(req.URL.Path)
+		switch req.Method {
+		case http.MethodGet:
+			// handled below
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			c.invalidate(resourceId)
+			return sender.Do(req)
+		case http.MethodPost:
+			// actions (e.g. `/start` or `/regenerateKey`) are performed against the resource the action is nested
+			// within - however actions which only retrieve data (e.g. `/listKeys`) don't modify it
+			if action := resourceId[strings.LastIndex(resourceId, "/")+1:]; !readCacheIsReadOnlyAction(action) {
+				c.invalidate(resourceId[:strings.LastIndex(resourceId, "/")])
+			}
+			return sender.Do(req)
+		default:
+			return sender.Do(req)
+		}
+
+		key := resourceId + "?" + req.URL.Query().Encode()
+		if entry := c.get(key); entry != nil {
+			log.Printf("[DEBUG] Using the cached response for GET %s", req.URL.Path)
+			return entry.response(req), nil
+		}
+
+		resp, err := sender.Do(req)
+		if err != nil || resp == nil || resp.StatusCode != http.StatusOK || !readCacheIsCacheable(resourceId, resp) {
+			return resp, err
+		}
+
+		body, ok := readCacheBody(resp)
+		if !ok || readCacheIsTransitioning(body) {
+			return resp, err
+		}
+
+		c.set(key, &readCacheEntry{
+			resourceId: resourceId,
+			statusCode: resp.StatusCode,
+			status:     resp.Status,
+			proto:      resp.Proto,
+			header:     resp.Header.Clone(),
+			body:       body,
+		})
+		return resp, err
+	})
+}
+
+func (c *ReadCache) get(key string) *readCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.entries[key]
+}
+
+func (c *ReadCache) set(key string, entry *readCacheEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// the resource may have been modified whilst the request was in-flight
+	if c.isModified(entry.resourceId) {
+		return
+	}
+
+	if c.options.MaxEntries > 0 && len(c.entries) >= c.options.MaxEntries {
+		return
+	}
+
+	c.entries[key] = entry
+}
+
+// invalidate removes the cached responses for the Resource ID and any nested resources, the parent resource
+// and anything nested within it (e.g. other Subnets within the same Virtual Network) - and the collection
+// containing the resource, which is returned when listing resources of that type
+func (c *ReadCache) invalidate(resourceId string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	scopes := []string{resourceId}
+	if parentId := readCacheParentId(resourceId); parentId != "" {
+		scopes = append(scopes, parentId)
+	}
+	collectionId := resourceId[:strings.LastIndex(resourceId, "/")]
+	c.modifiedCollections[collectionId] = struct{}{}
+	for _, scope := range scopes {
+		c.modified[scope] = struct{}{}
+	}
+
+	for key, entry := range c.entries {
+		if entry.resourceId == collectionId {
+			delete(c.entries, key)
+			continue
+		}
+		for _, scope := range scopes {
+			if readCacheIsWithin(entry.resourceId, scope) {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// isModified returns whether the Resource ID (or a resource it's nested within) has been modified during this
+// run, the lock must be held by the caller
+func (c *ReadCache) isModified(resourceId string) bool {
+	if _, ok := c.modified[resourceId]; ok {
+		return true
+	}
+	if _, ok := c.modifiedCollections[resourceId]; ok {
+		return true
+	}
+
+	for id := range c.modified {
+		if readCacheIsWithin(resourceId, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// response returns a copy of the cached response for the specified request
+func (e *readCacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         e.proto,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// readCacheResourceId returns the normalized Resource ID from the path of the request
+func readCacheResourceId(path string) string {
+	return strings.ToLower("/" + strings.Trim(path, "/"))
+}
+
+// readCacheParentId returns the Resource ID of the parent resource (e.g. the Virtual Network for a Subnet),
+// or an empty string when the resource isn't nested within another resource
+func readCacheParentId(resourceId string) string {
+	segments := strings.Split(strings.Trim(resourceId, "/"), "/")
+
+	// a nested resource is at least `{scope}/providers/{namespace}/{type}/{name}/{type}/{name}`
+	for i, v := range segments {
+		if v == "providers" && len(segments)-i >= 6 {
+			return "/" + strings.Join(segments[:len(segments)-2], "/")
+		}
+	}
+	return ""
+}
+
+// readCacheIsReadOnlyAction returns whether the (lower-cased) action performed using a POST request only
+// retrieves data, such as the keys or connection strings for the resource (e.g. `listKeys` or `listSecrets`)
+func readCacheIsReadOnlyAction(action string) bool {
+	return strings.HasPrefix(action, "list")
+}
+
+// readCacheIsWithin returns whether the Resource ID is (or is nested within) the parent Resource ID
+func readCacheIsWithin(resourceId, parentId string) bool {
+	return resourceId == parentId || strings.HasPrefix(resourceId, parentId+"/")
+}
+
+// readCacheIsCacheable returns whether the response can be cached - responses from the operation status
+// endpoints polled for Long Running Operations (which are retrieved until they complete) aren't cached
+func readCacheIsCacheable(resourceId string, resp *http.Response) bool {
+	if resp.Header.Get("Retry-After") != "" || resp.Header.Get("Azure-AsyncOperation") != "" || resp.Header.Get("Location") != "" {
+		return false
+	}
+
+	for _, segment := range strings.Split(resourceId, "/") {
+		if strings.Contains(segment, "operation") {
+			return false
+		}
+	}
+	return true
+}
+
+// readCacheBody returns the body of the response, leaving the body intact so that it can be read again - the
+// body isn't returned when it can't be read or exceeds the maximum size which is cached
+func readCacheBody(resp *http.Response) ([]byte, bool) {
+	if resp.Body == nil {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, readCacheMaxBodySize+1))
+	// the remainder of the body (if it exceeded the limit) is retained, so that the response isn't truncated
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+		Closer: resp.Body,
+	}
+	if err != nil || len(body) > readCacheMaxBodySize {
+		return nil, false
+	}
+	return body, true
+}
+
+// readCacheIsTransitioning returns whether the resource is being provisioned (or deleted), in which case it's
+// polled until it reaches a terminal state and as such shouldn't be cached
+func readCacheIsTransitioning(body []byte) bool {
+	var payload struct {
+		Properties *struct {
+			ProvisioningState string `json:"provisioningState"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Properties == nil {
+		return false
+	}
+
+	switch strings.ToLower(payload.Properties.ProvisioningState) {
+	case "", "succeeded", "failed", "canceled", "cancelled":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	readCacheTestVirtualNetworkId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/vnet1"
+	readCacheTestSubnetId         = readCacheTestVirtualNetworkId + "/subnets/subnet1"
+)
+
+// readCacheTestSender counts the requests sent for each path, returning the specified body for GET requests
+type readCacheTestSender struct {
+	requests map[string]int
+	body     string
+}
+
+func (s *readCacheTestSender) Do(req *http.Request) (*http.Response, error) {
+	s.requests[req.Method+" "+req.URL.Path]++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func sendReadCacheTestRequest(t *testing.T, sender autorest.Sender, method, host, path string) string {
+	req, _ := http.NewRequest(method, "https://"+host+path+"?api-version=2021-05-01", nil)
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending %s %s: %+v", method, path, err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading the body for %s %s: %+v", method, path, err)
+	}
+	return string(body)
+}
+
+func TestReadCacheSender(t *testing.T) {
+	if cache := NewReadCache("https://management.azure.com/", ReadCacheOptions{}); cache != nil {
+		t.Fatalf("expected no cache when caching is disabled")
+	}
+
+	fake := &readCacheTestSender{
+		requests: map[string]int{},
+		body:     `{"properties":{"provisioningState":"Succeeded"}}`,
+	}
+	cache := NewReadCache("https://management.azure.com/", ReadCacheOptions{Enabled: true})
+	sender := cache.Sender(fake)
+
+	for i := 0; i < 3; i++ {
+		if body := sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestVirtualNetworkId); body != fake.body {
+			t.Fatalf("expected the body %q but got %q", fake.body, body)
+		}
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestSubnetId)
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "example.vault.azure.net", "/secrets/example")
+	}
+	if v := fake.requests["GET "+readCacheTestVirtualNetworkId]; v != 1 {
+		t.Fatalf("expected the Virtual Network to be retrieved once but got %d", v)
+	}
+	if v := fake.requests["GET /secrets/example"]; v != 3 {
+		t.Fatalf("expected requests to Data Plane API's not to be cached but got %d requests", v)
+	}
+
+	// modifying the Subnet invalidates both the Subnet and the Virtual Network, which aren't cached again
+	sendReadCacheTestRequest(t, sender, http.MethodPut, "management.azure.com", readCacheTestSubnetId)
+	for i := 0; i < 2; i++ {
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestVirtualNetworkId)
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestSubnetId)
+	}
+	if v := fake.requests["GET "+readCacheTestVirtualNetworkId]; v != 3 {
+		t.Fatalf("expected the Virtual Network to be retrieved 3 times but got %d", v)
+	}
+	if v := fake.requests["GET "+readCacheTestSubnetId]; v != 3 {
+		t.Fatalf("expected the Subnet to be retrieved 3 times but got %d", v)
+	}
+}
+
+func TestReadCacheSenderAction(t *testing.T) {
+	fake := &readCacheTestSender{
+		requests: map[string]int{},
+		body:     `{"properties":{"provisioningState":"Succeeded"}}`,
+	}
+	sender := NewReadCache("https://management.azure.com/", ReadCacheOptions{Enabled: true}).Sender(fake)
+
+	sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestVirtualNetworkId)
+	sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestSubnetId)
+
+	// performing an action on the Subnet invalidates both the Subnet and the Virtual Network
+	sendReadCacheTestRequest(t, sender, http.MethodPost, "management.azure.com", readCacheTestSubnetId+"/prepareNetworkPolicies")
+	sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestVirtualNetworkId)
+	sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestSubnetId)
+	if v := fake.requests["GET "+readCacheTestVirtualNetworkId]; v != 2 {
+		t.Fatalf("expected the Virtual Network to be retrieved twice but got %d", v)
+	}
+	if v := fake.requests["GET "+readCacheTestSubnetId]; v != 2 {
+		t.Fatalf("expected the Subnet to be retrieved twice but got %d", v)
+	}
+}
+
+func TestReadCacheSenderReadOnlyAction(t *testing.T) {
+	fake := &readCacheTestSender{
+		requests: map[string]int{},
+		body:     `{"properties":{"provisioningState":"Succeeded"}}`,
+	}
+	sender := NewReadCache("https://management.azure.com/", ReadCacheOptions{Enabled: true}).Sender(fake)
+
+	// refreshing a Storage Account and the Containers within it retrieves the Storage Account and its keys
+	storageAccountId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/account1"
+	for i := 0; i < 3; i++ {
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", storageAccountId)
+		sendReadCacheTestRequest(t, sender, http.MethodPost, "management.azure.com", storageAccountId+"/listKeys")
+	}
+	if v := fake.requests["GET "+storageAccountId]; v != 1 {
+		t.Fatalf("expected the Storage Account to be retrieved once but got %d", v)
+	}
+	if v := fake.requests["POST "+storageAccountId+"/listKeys"]; v != 3 {
+		t.Fatalf("expected the keys for the Storage Account not to be cached but got %d requests", v)
+	}
+
+	// whereas regenerating a key modifies the Storage Account
+	sendReadCacheTestRequest(t, sender, http.MethodPost, "management.azure.com", storageAccountId+"/regenerateKey")
+	for i := 0; i < 2; i++ {
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", storageAccountId)
+	}
+	if v := fake.requests["GET "+storageAccountId]; v != 3 {
+		t.Fatalf("expected the Storage Account to be retrieved 3 times but got %d", v)
+	}
+}
+
+func TestReadCacheSenderTransitioning(t *testing.T) {
+	fake := &readCacheTestSender{
+		requests: map[string]int{},
+		body:     `{"properties":{"provisioningState":"Updating"}}`,
+	}
+	sender := NewReadCache("https://management.azure.com/", ReadCacheOptions{Enabled: true}).Sender(fake)
+
+	for i := 0; i < 2; i++ {
+		sendReadCacheTestRequest(t, sender, http.MethodGet, "management.azure.com", readCacheTestVirtualNetworkId)
+	}
+	if v := fake.requests["GET "+readCacheTestVirtualNetworkId]; v != 2 {
+		t.Fatalf("expected a resource which is being provisioned not to be cached but got %d requests", v)
+	}
+}
+
+func TestReadCacheParentId(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    strings.ToLower(readCacheTestSubnetId),
+			expected: strings.ToLower(readCacheTestVirtualNetworkId),
+		},
+		{
+			input:    strings.ToLower(readCacheTestVirtualNetworkId),
+			expected: "",
+		},
+		{
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example",
+			expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		if actual := readCacheParentId(v.input); actual != v.expected {
+			t.Fatalf("expected the parent of %q to be %q but got %q", v.input, v.expected, actual)
+		}
+	}
+}
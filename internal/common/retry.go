@@ -0,0 +1,214 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// retryBaseBackoff is the delay before the first retry, which is doubled for each subsequent attempt
+	retryBaseBackoff = 2 * time.Second
+
+	// retryMaxErrorBodySize is the maximum size of a response body which is inspected for an ARM error code
+	retryMaxErrorBodySize = 64 * 1024
+)
+
+// DefaultRetryableErrorCodes are the ARM error codes which are retried by default, these are returned when a
+// conflicting operation is in progress on the resource (or a related resource), which resolves itself once
+// that operation has completed.
+var DefaultRetryableErrorCodes = []string{
+	"AnotherOperationInProgress",
+	"RetryableError",
+}
+
+// RetryOptions configures how requests sent to Azure Resource Manager are retried when throttled, or when a
+// transient error is returned - the zero value (used when the `retry` block is omitted) disables these retries,
+// in which case requests are retried by autorest as usual.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the initial request
+	MaxAttempts int
+
+	// MaxBackoff is the maximum delay between attempts, when not using the `Retry-After` header
+	MaxBackoff time.Duration
+
+	// UseRetryAfterHeader specifies whether the delay from the `Retry-After` header is used when returned
+	UseRetryAfterHeader bool
+
+	// ErrorCodes is a list of ARM error codes (from the `error.code` field in the response) which are retried
+	ErrorCodes []string
+}
+
+// Configured returns whether these RetryOptions have been specified, in which case requests sent using
+// `client.Send` are retried only by withRetries rather than also by autorest
+func (o RetryOptions) Configured() bool {
+	return o.MaxAttempts > 0
+}
+
+// Enabled returns whether requests should be retried at all
+func (o RetryOptions) Enabled() bool {
+	return o.MaxAttempts > 1
+}
+
+// withRetries returns a Sender which retries requests sent via the specified Sender according to the
+// RetryOptions - a request is retried when:
+//
+//   - Azure is throttling requests (e.g. a `429 Too Many Requests` response).
+//   - the response contains one of the configured ARM error codes.
+//   - a `5xx` response is returned for an idempotent request - other requests (e.g. a POST) aren't
+//     retried, since Azure may have started processing the request.
+func withRetries(sender autorest.Sender, options RetryOptions) autorest.Sender {
+	if !options.Enabled() {
+		return sender
+	}
+
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		// the request body is buffered, so that it can be sent again
+		rr := autorest.NewRetriableRequest(req)
+
+		var resp *http.Response
+		var err error
+		for attempt := 1; ; attempt++ {
+			if err := rr.Prepare(); err != nil {
+				return resp, err
+			}
+
+			autorest.DrainResponseBody(resp)
+			resp, err = sender.Do(rr.Request())
+			if err != nil || attempt >= options.MaxAttempts {
+				return resp, err
+			}
+
+			retry, reason := shouldRetry(req.Method, resp, options.ErrorCodes)
+			if !retry {
+				return resp, nil
+			}
+
+			delay := retryBackoff(attempt, options.MaxBackoff)
+			if options.UseRetryAfterHeader {
+				if v, ok := retryAfter(resp); ok {
+					delay = v
+				}
+			}
+
+			log.Printf("[DEBUG] Retrying %s %s in %s (attempt %d of %d) since %s", req.Method, req.URL.Path, delay, attempt+1, options.MaxAttempts, reason)
+			select {
+			case <-time.After(delay):
+			case <-req.Context().Done():
+				return resp, req.Context().Err()
+			}
+		}
+	})
+}
+
+// shouldRetry returns whether the request should be retried based on the response - and if so, why
+func shouldRetry(method string, resp *http.Response, errorCodes []string) (bool, string) {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return false, ""
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, "the request was throttled"
+	}
+
+	if code := armErrorCode(resp); code != "" {
+		for _, v := range errorCodes {
+			if strings.EqualFold(v, code) {
+				return true, "the error code " + code + " was returned"
+			}
+		}
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented && isIdempotent(method) {
+		return true, "the status code " + strconv.Itoa(resp.StatusCode) + " was returned"
+	}
+
+	return false, ""
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// armErrorCode returns the ARM error code from the body of the response (if any), leaving the
+// body intact so that it can be read again
+func armErrorCode(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, retryMaxErrorBodySize))
+	// the remainder of the body (if it exceeded the limit) is retained, so that the response isn't truncated
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+		Closer: resp.Body,
+	}
+	if err != nil {
+		return ""
+	}
+
+	// most Resource Providers nest the error within an `error` object, however some return it at the top-level
+	var payload struct {
+		Code  string `json:"code"`
+		Error *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	if payload.Error != nil && payload.Error.Code != "" {
+		return payload.Error.Code
+	}
+	return payload.Code
+}
+
+// retryBackoff returns the exponential backoff for the specified attempt, capped at max
+func retryBackoff(attempt int, max time.Duration) time.Duration {
+	delay := retryBaseBackoff
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+
+	if max > 0 && delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// retryAfter returns the delay specified in the `Retry-After` header of the response, which is either
+// a number of seconds or a HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,306 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+type fakeRetryResponse struct {
+	statusCode int
+	body       string
+	retryAfter string
+}
+
+func fakeRetrySender(responses []fakeRetryResponse, bodies *[]string) autorest.Sender {
+	attempt := 0
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.Body != nil {
+			raw, _ := io.ReadAll(req.Body)
+			body = string(raw)
+		}
+		*bodies = append(*bodies, body)
+
+		v := responses[attempt]
+		if attempt < len(responses)-1 {
+			attempt++
+		}
+
+		resp := &http.Response{
+			StatusCode: v.statusCode,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(v.body)),
+			Request:    req,
+		}
+		if v.retryAfter != "" {
+			resp.Header.Set("Retry-After", v.retryAfter)
+		}
+		return resp, nil
+	})
+}
+
+func TestWithRetries(t *testing.T) {
+	options := RetryOptions{
+		MaxAttempts:         3,
+		MaxBackoff:          time.Millisecond,
+		UseRetryAfterHeader: true,
+		ErrorCodes:          DefaultRetryableErrorCodes,
+	}
+
+	testData := []struct {
+		name           string
+		method         string
+		responses      []fakeRetryResponse
+		expectedStatus int
+		expectedSent   int
+	}{
+		{
+			name:           "success",
+			method:         http.MethodPut,
+			responses:      []fakeRetryResponse{{statusCode: http.StatusOK}},
+			expectedStatus: http.StatusOK,
+			expectedSent:   1,
+		},
+		{
+			name:   "throttled",
+			method: http.MethodPost,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusTooManyRequests, retryAfter: "0"},
+				{statusCode: http.StatusOK},
+			},
+			expectedStatus: http.StatusOK,
+			expectedSent:   2,
+		},
+		{
+			name:   "retryable error code",
+			method: http.MethodPut,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusConflict, body: `{"error":{"code":"AnotherOperationInProgress","message":"busy"}}`},
+				{statusCode: http.StatusConflict, body: `{"code":"RetryableError"}`},
+				{statusCode: http.StatusCreated},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedSent:   3,
+		},
+		{
+			name:   "non-retryable error code",
+			method: http.MethodPut,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusConflict, body: `{"error":{"code":"Conflict"}}`},
+				{statusCode: http.StatusOK},
+			},
+			expectedStatus: http.StatusConflict,
+			expectedSent:   1,
+		},
+		{
+			name:   "server error for an idempotent request",
+			method: http.MethodGet,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusOK},
+			},
+			expectedStatus: http.StatusOK,
+			expectedSent:   2,
+		},
+		{
+			name:   "server error for a non-idempotent request",
+			method: http.MethodPost,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusOK},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedSent:   1,
+		},
+		{
+			name:   "attempts exhausted",
+			method: http.MethodGet,
+			responses: []fakeRetryResponse{
+				{statusCode: http.StatusTooManyRequests},
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedSent:   3,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		bodies := make([]string, 0)
+		sender := withRetries(fakeRetrySender(v.responses, &bodies), options)
+
+		req, _ := http.NewRequest(v.method, "https://management.azure.com/example", strings.NewReader("payload"))
+		resp, err := sender.Do(req)
+		if err != nil {
+			t.Fatalf("sending the request: %+v", err)
+		}
+		if resp.StatusCode != v.expectedStatus {
+			t.Fatalf("expected the status code %d but got %d", v.expectedStatus, resp.StatusCode)
+		}
+		if len(bodies) != v.expectedSent {
+			t.Fatalf("expected the request to be sent %d times but got %d", v.expectedSent, len(bodies))
+		}
+		for _, body := range bodies {
+			if body != "payload" {
+				t.Fatalf("expected the request body to be sent on each attempt but got %q", body)
+			}
+		}
+	}
+}
+
+func TestWithRetriesPreservesResponseBody(t *testing.T) {
+	bodies := make([]string, 0)
+	sender := withRetries(fakeRetrySender([]fakeRetryResponse{
+		{statusCode: http.StatusBadRequest, body: `{"error":{"code":"InvalidParameter"}}`},
+	}, &bodies), RetryOptions{MaxAttempts: 2, ErrorCodes: DefaultRetryableErrorCodes})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/example", nil)
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending the request: %+v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"error":{"code":"InvalidParameter"}}` {
+		t.Fatalf("expected the response body to be preserved but got %q", string(body))
+	}
+}
+
+func TestWithRetriesDisabled(t *testing.T) {
+	for _, options := range []RetryOptions{{}, {MaxAttempts: 1}} {
+		bodies := make([]string, 0)
+		sender := withRetries(fakeRetrySender([]fakeRetryResponse{{statusCode: http.StatusTooManyRequests}}, &bodies), options)
+
+		req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/example", nil)
+		if _, err := sender.Do(req); err != nil {
+			t.Fatalf("sending the request: %+v", err)
+		}
+		if len(bodies) != 1 {
+			t.Fatalf("expected the request to be sent once when retries are disabled but got %d", len(bodies))
+		}
+	}
+}
+
+func TestWithRetriesCancelled(t *testing.T) {
+	bodies := make([]string, 0)
+	sender := withRetries(fakeRetrySender([]fakeRetryResponse{
+		{statusCode: http.StatusTooManyRequests, retryAfter: "60"},
+	}, &bodies), RetryOptions{MaxAttempts: 3, UseRetryAfterHeader: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/example", nil)
+	if _, err := sender.Do(req); err != context.DeadlineExceeded {
+		t.Fatalf("expected the retries to stop when the context is cancelled but got %+v", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	testData := []struct {
+		attempt  int
+		max      time.Duration
+		expected time.Duration
+	}{
+		{attempt: 1, max: time.Minute, expected: 2 * time.Second},
+		{attempt: 2, max: time.Minute, expected: 4 * time.Second},
+		{attempt: 4, max: time.Minute, expected: 16 * time.Second},
+		{attempt: 10, max: time.Minute, expected: time.Minute},
+		{attempt: 1, max: time.Second, expected: time.Second},
+	}
+
+	for _, v := range testData {
+		if actual := retryBackoff(v.attempt, v.max); actual != v.expected {
+			t.Fatalf("expected the backoff for attempt %d to be %s but got %s", v.attempt, v.expected, actual)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := retryAfter(resp); ok {
+		t.Fatalf("expected no delay when the header is omitted")
+	}
+
+	resp.Header.Set("Retry-After", "30")
+	if v, ok := retryAfter(resp); !ok || v != 30*time.Second {
+		t.Fatalf("expected a delay of 30s but got %s", v)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if v, ok := retryAfter(resp); !ok || v < 59*time.Minute {
+		t.Fatalf("expected a delay of around an hour but got %s", v)
+	}
+
+	resp.Header.Set("Retry-After", "invalid")
+	if _, ok := retryAfter(resp); ok {
+		t.Fatalf("expected an invalid header to be ignored")
+	}
+}
+
+func TestRetryConfigureClient(t *testing.T) {
+	testData := []struct {
+		name         string
+		retry        RetryOptions
+		expectedSent int
+	}{
+		{
+			// autorest retries the request using the Send Decorator passed by the generated SDK
+			name:         "not configured",
+			retry:        RetryOptions{},
+			expectedSent: 2,
+		},
+		{
+			// the request is only retried by withRetries
+			name: "configured",
+			retry: RetryOptions{
+				MaxAttempts: 3,
+				MaxBackoff:  time.Millisecond,
+			},
+			expectedSent: 3,
+		},
+		{
+			name: "configured without retries",
+			retry: RetryOptions{
+				MaxAttempts: 1,
+			},
+			expectedSent: 1,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		sent := make([]string, 0)
+		client := autorest.NewClientWithUserAgent("")
+		ClientOptions{
+			CustomSender: fakeRetrySender([]fakeRetryResponse{{statusCode: http.StatusTooManyRequests, retryAfter: "0"}}, &sent),
+			Retry:        v.retry,
+		}.ConfigureClient(&client, nil)
+		client.RetryAttempts = 1
+		client.RetryDuration = time.Millisecond
+
+		req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example", nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := client.Send(req, azure.DoRetryWithRegistration(client))
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected the status code %d but got %d", http.StatusTooManyRequests, resp.StatusCode)
+		}
+		if len(sent) != v.expectedSent {
+			t.Fatalf("expected the request to be sent %d times but got %d", v.expectedSent, len(sent))
+		}
+	}
+}
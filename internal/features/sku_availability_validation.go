@@ -0,0 +1,22 @@
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// SkuAvailabilityValidationEnabled returns whether or not the feature for validating that the
+// SKU's used by Virtual Machines, Virtual Machine Scale Sets and Managed Disks are available
+// (in the specified Location and Zones) during the plan is enabled.
+//
+// This functionality calls out to the Azure Resource Manager API to retrieve (and cache on disk)
+// the list of Compute SKU's available to the Subscription - and as such is opt-in, and can be
+// enabled by setting the Environment Variable `ARM_PROVIDER_SKU_VALIDATION` to `true` when
+// Enhanced Validation is enabled.
+func SkuAvailabilityValidationEnabled() bool {
+	if !EnhancedValidationEnabled() {
+		return false
+	}
+
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_SKU_VALIDATION"), "true")
+}
@@ -0,0 +1,23 @@
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// StructuredLoggingEnabled returns whether or not the Typed Resources should write their log
+// messages as JSON lines (containing the Resource Type, Resource ID, Operation, Correlation
+// Request ID and elapsed time) rather than as free-form text
+//
+// This is intended for shipping the Provider logs to a log pipeline during large applies, and
+// can be enabled by setting the Environment Variable `ARM_PROVIDER_LOG_FORMAT` to `json`.
+func StructuredLoggingEnabled() bool {
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_LOG_FORMAT"), "json")
+}
+
+// StructuredLoggingFile returns the path to the file which the JSON lines should be appended to
+// (when Structured Logging is enabled) - which can be set using the Environment Variable
+// `ARM_PROVIDER_LOG_FILE`. When this isn't set the JSON lines are written to stderr.
+func StructuredLoggingFile() string {
+	return os.Getenv("ARM_PROVIDER_LOG_FILE")
+}
@@ -1,24 +1,46 @@
 package locks
 
+import (
+	"context"
+	"sort"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = NewMutexKV()
 
-func ByID(id string) {
-	armMutexKV.Lock(id)
+// ByID locks the specified ID, returning an error if the lock can't be acquired before
+// the context is done (for example, when the timeout for the current operation is reached)
+func ByID(ctx context.Context, id string) error {
+	return armMutexKV.LockWithContext(ctx, id)
 }
 
-// handle the case of using the same name for different kinds of resources
-func ByName(name string, resourceType string) {
+// ByName locks the specified name for this resource type (to handle the case of using the same name
+// for different kinds of resources), returning an error if the lock can't be acquired before the
+// context is done
+func ByName(ctx context.Context, name string, resourceType string) error {
 	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+	return armMutexKV.LockWithContext(ctx, updatedName)
 }
 
-func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+// MultipleByName locks each of the specified names for this resource type, returning an error if
+// the locks can't be acquired before the context is done, in which case any locks which have been
+// acquired are released.
+//
+// The locks are acquired in a canonical (sorted) order, so that callers locking an overlapping set
+// of names can't deadlock by acquiring them in a different order.
+func MultipleByName(ctx context.Context, names *[]string, resourceType string) error {
+	newSlice := canonicalNames(*names)
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+	for i, name := range newSlice {
+		if err := ByName(ctx, name, resourceType); err != nil {
+			for _, acquired := range newSlice[:i] {
+				UnlockByName(acquired, resourceType)
+			}
+			return err
+		}
 	}
+
+	return nil
 }
 
 func UnlockByID(id string) {
@@ -31,9 +53,16 @@ func UnlockByName(name string, resourceType string) {
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	newSlice := canonicalNames(*names)
 
 	for _, name := range newSlice {
 		UnlockByName(name, resourceType)
 	}
 }
+
+// canonicalNames returns the unique names in a consistent (sorted) order
+func canonicalNames(names []string) []string {
+	newSlice := removeDuplicatesFromStringArray(names)
+	sort.Strings(newSlice)
+	return newSlice
+}
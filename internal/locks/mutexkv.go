@@ -1,48 +1,153 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
+//
+// Entries are reference counted (by both the holder and any waiters) and are removed
+// once they're no longer referenced, so that idle keys don't accumulate over time.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*lockEntry
+}
+
+type lockEntry struct {
+	// semaphore has a capacity of one and contains a value whilst the lock is held
+	semaphore chan struct{}
+
+	// references is the number of callers either holding or waiting for this lock
+	references int
+
+	// waiters is the number of callers waiting for this lock
+	waiters int
+
+	// heldSince is the time at which the current holder acquired this lock
+	heldSince time.Time
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *mutexKV) Lock(key string) {
+	// a context without a deadline can't time out, so there's no error to handle
+	_ = m.LockWithContext(context.Background(), key)
+}
+
+// LockWithContext locks the mutex for the given key, waiting until either the lock is acquired
+// or the context is done - in which case an error containing the locks held at that time is
+// returned. Caller is responsible for calling Unlock for the same key when this returns nil
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+
+	m.lock.Lock()
+	entry, ok := m.store[key]
+	if !ok {
+		entry = &lockEntry{
+			semaphore: make(chan struct{}, 1),
+		}
+		m.store[key] = entry
+	}
+	entry.references++
+	entry.waiters++
+	m.lock.Unlock()
+
+	select {
+	case entry.semaphore <- struct{}{}:
+		m.lock.Lock()
+		entry.waiters--
+		entry.heldSince = time.Now()
+		m.lock.Unlock()
+
+		log.Printf("[DEBUG] Locked %q", key)
+		return nil
+
+	case <-ctx.Done():
+		// capture the held locks before removing this waiter, so that it's included
+		held := m.dump()
+
+		m.lock.Lock()
+		entry.waiters--
+		m.release(key, entry)
+		m.lock.Unlock()
+
+		log.Printf("[DEBUG] Timed out waiting to lock %q - the locks held at this time are:\n%s", key, held)
+		return fmt.Errorf("waiting to acquire the lock %q: %+v\n\nThe locks held at this time are:\n%s", key, ctx.Err(), held)
+	}
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, ok := m.store[key]
+	if !ok {
+		panic(fmt.Sprintf("unlocking %q which isn't locked", key))
+	}
+
+	select {
+	case <-entry.semaphore:
+	default:
+		panic(fmt.Sprintf("unlocking %q which isn't locked", key))
+	}
+
+	entry.heldSince = time.Time{}
+	m.release(key, entry)
+
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
-// Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+// release removes a reference to the entry for the given key, removing the entry once it's no
+// longer referenced. The caller must hold `m.lock`
+func (m *mutexKV) release(key string, entry *lockEntry) {
+	entry.references--
+	if entry.references == 0 {
+		delete(m.store, key)
+	}
+}
+
+// dump returns a summary of the locks which are currently held or being waited on
+func (m *mutexKV) dump() string {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	mutex, ok := m.store[key]
-	if !ok {
-		mutex = &sync.Mutex{}
-		m.store[key] = mutex
+
+	keys := make([]string, 0, len(m.store))
+	for k := range m.store {
+		keys = append(keys, k)
 	}
-	return mutex
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		return "(none)"
+	}
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entry := m.store[key]
+		status := "not held"
+		if !entry.heldSince.IsZero() {
+			status = fmt.Sprintf("held for %s", time.Since(entry.heldSince).Round(time.Second))
+		}
+		lines = append(lines, fmt.Sprintf("- %q: %s, %d waiting", key, status, entry.waiters))
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 // Returns a properly initialized mutexKV
 func NewMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*lockEntry),
 	}
 }
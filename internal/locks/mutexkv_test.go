@@ -0,0 +1,116 @@
+package locks
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKVRemovesIdleKeys(t *testing.T) {
+	kv := NewMutexKV()
+
+	kv.Lock("first")
+	kv.Lock("second")
+	if len(kv.store) != 2 {
+		t.Fatalf("expected 2 entries whilst locked but got %d", len(kv.store))
+	}
+
+	kv.Unlock("first")
+	kv.Unlock("second")
+	if len(kv.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(kv.store))
+	}
+}
+
+func TestMutexKVLockWithContextTimesOut(t *testing.T) {
+	kv := NewMutexKV()
+	kv.Lock("example")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := kv.LockWithContext(ctx, "example")
+	if err == nil {
+		t.Fatalf("expected an error when the lock couldn't be acquired")
+	}
+	if !strings.Contains(err.Error(), `- "example": held for`) || !strings.Contains(err.Error(), "1 waiting") {
+		t.Fatalf("expected the error to contain the held locks and waiters but got: %+v", err)
+	}
+
+	kv.Unlock("example")
+	if len(kv.store) != 0 {
+		t.Fatalf("expected no entries once the waiter timed out and the holder unlocked but got %d", len(kv.store))
+	}
+
+	if err := kv.LockWithContext(context.Background(), "example"); err != nil {
+		t.Fatalf("expected the lock to be acquired once released but got: %+v", err)
+	}
+	kv.Unlock("example")
+}
+
+func TestMutexKVUnlockWhenNotLocked(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected unlocking a key which isn't locked to panic")
+		}
+	}()
+
+	NewMutexKV().Unlock("example")
+}
+
+func TestMultipleByNameOverlappingNames(t *testing.T) {
+	resourceType := "azurerm_example_overlapping"
+	first := []string{"a", "b", "c"}
+	second := []string{"c", "b", "a"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// acquiring the same names in a different order would deadlock without a canonical ordering
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 100; i++ {
+		for _, names := range [][]string{first, second} {
+			names := names
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := MultipleByName(ctx, &names, resourceType); err != nil {
+					errs <- err
+					return
+				}
+				UnlockMultipleByName(&names, resourceType)
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("acquiring locks: %+v", err)
+	}
+}
+
+func TestMultipleByNameReleasesOnTimeout(t *testing.T) {
+	resourceType := "azurerm_example_release"
+	if err := ByName(context.Background(), "b", resourceType); err != nil {
+		t.Fatalf("acquiring lock: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	names := []string{"b", "a"}
+	if err := MultipleByName(ctx, &names, resourceType); err == nil {
+		t.Fatalf("expected an error when one of the locks couldn't be acquired")
+	}
+
+	// `a` is acquired first (since it's sorted) and should have been released
+	if err := ByName(context.Background(), "a", resourceType); err != nil {
+		t.Fatalf("expected `a` to have been released: %+v", err)
+	}
+	UnlockByName("a", resourceType)
+	UnlockByName("b", resourceType)
+}
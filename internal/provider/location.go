@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/catalogue"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// withLocationCatalogue updates the validation for each `location` field within the Schema for the specified
+// Resource to warn when the Location isn't present in the offline catalogue for this Azure Environment - which
+// is only used when the Locations couldn't be retrieved from Azure (for example when offline).
+func withLocationCatalogue(resource *pluginsdk.Resource) {
+	var walk func(input map[string]*pluginsdk.Schema)
+	walk = func(input map[string]*pluginsdk.Schema) {
+		for k, v := range input {
+			if k == "location" && v.Type == pluginsdk.TypeString && v.ValidateFunc != nil {
+				v.ValidateFunc = validateLocationWithCatalogue(v.ValidateFunc)
+			}
+
+			if elem, ok := v.Elem.(*pluginsdk.Resource); ok {
+				walk(elem.Schema)
+			}
+		}
+	}
+	walk(resource.Schema)
+}
+
+func validateLocationWithCatalogue(validateFunc pluginsdk.SchemaValidateFunc) pluginsdk.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		warnings, errors := validateFunc(i, k)
+		if len(errors) > 0 || !features.EnhancedValidationEnabled() || catalogue.LiveDataAvailable() {
+			return warnings, errors
+		}
+
+		c := catalogue.Current()
+		if c == nil {
+			return warnings, errors
+		}
+
+		v, ok := i.(string)
+		if !ok || strings.EqualFold(location.Normalize(v), "global") {
+			return warnings, errors
+		}
+
+		// the catalogue is a snapshot taken when the Provider was released and Locations are added to Azure
+		// between releases, so a Location which isn't present may still be valid and is only a warning
+		if !c.HasLocation(v) {
+			warnings = append(warnings, fmt.Sprintf("%q was not found in the list of Azure Locations for the %q Azure Environment (from the offline catalogue version %q, which may be out of date): %q", location.Normalize(v), c.Environment, c.Version, strings.Join(c.Locations, ",")))
+		}
+
+		return warnings, errors
+	}
+}
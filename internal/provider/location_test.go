@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/catalogue"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestLocationCatalogue(t *testing.T) {
+	if !features.EnhancedValidationEnabled() {
+		t.Skip("Enhanced Validation is disabled")
+	}
+
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+			"replica": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"location": commonschema.Location(),
+					},
+				},
+			},
+		},
+	}
+	withLocationCatalogue(resource)
+
+	testCases := []struct {
+		input   string
+		live    bool
+		valid   bool
+		warning bool
+	}{
+		{input: "", valid: false},
+		{input: "West Europe", valid: true},
+		{input: "westeurope", valid: true},
+		{input: "global", valid: true},
+		{input: "West Eurpoe", valid: true, warning: true},
+		{input: "West Eurpoe", live: true, valid: true, warning: false},
+	}
+
+	defer catalogue.SetLiveDataAvailable(catalogue.LiveDataAvailable())
+
+	nested := resource.Schema["replica"].Elem.(*pluginsdk.Resource).Schema["location"]
+	for _, field := range []*pluginsdk.Schema{resource.Schema["location"], nested} {
+		for _, tc := range testCases {
+			catalogue.SetLiveDataAvailable(tc.live)
+
+			warnings, errors := field.ValidateFunc(tc.input, "location")
+			if valid := len(errors) == 0; valid != tc.valid {
+				t.Fatalf("expected %q to be valid (%t) but got %t: %+v", tc.input, tc.valid, valid, errors)
+			}
+			if warning := len(warnings) > 0; warning != tc.warning {
+				t.Fatalf("expected a warning for %q (live: %t) to be %t but got %t: %+v", tc.input, tc.live, tc.warning, warning, warnings)
+			}
+		}
+	}
+}
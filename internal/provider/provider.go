@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,6 +26,15 @@ func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithSender returns the Azure Provider for use in the Acceptance Tests, which sends
+// all requests to Resource Manager using the specified Sender. When skipAuthentication is true no tokens
+// are obtained from Azure Active Directory, which allows recorded requests to be replayed offline.
+func TestAzureProviderWithSender(sender autorest.Sender, skipAuthentication bool) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigureWithSender(p, sender, skipAuthentication)
+	return p
+}
+
 func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 	// avoids this showing up in test output
 	debugLog := func(f string, v ...interface{}) {
@@ -97,6 +107,30 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		}
 	}
 
+	// locations are checked against the offline catalogue when these couldn't be retrieved from Azure
+	for _, v := range dataSources {
+		withLocationCatalogue(v)
+	}
+	for _, v := range resources {
+		withLocationCatalogue(v)
+	}
+
+	// the requests sent to Azure Resource Manager are summarised for each operation against each resource - and
+	// resources exposing a configurable `tags` field support the `default_tags`, `ignore_tags` and `tag_policy`
+	for k, v := range dataSources {
+		wrapResourceFunctions(v, withTelemetry(fmt.Sprintf("data.%s", k)))
+	}
+	for k, v := range resources {
+		wrappers := []resourceFuncWrapper{
+			withTelemetry(k),
+		}
+		if resourceSupportsTags(v) {
+			wrappers = append(wrappers, withProviderTags(k, v))
+		}
+
+		wrapResourceFunctions(v, wrappers...)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -226,6 +260,18 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"tag_policy": schemaTagPolicy(),
+
+			"retry": schemaRetry(),
+
+			"rate_limit": schemaRateLimit(),
+
+			"read_cache": schemaReadCache(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -252,6 +298,10 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 }
 
 func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+	return providerConfigureWithSender(p, nil, false)
+}
+
+func providerConfigureWithSender(p *schema.Provider, sender autorest.Sender, skipAuthentication bool) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -300,6 +350,11 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.Errorf("building AzureRM Client: %s", err)
 		}
 
+		tagPolicy, err := expandTagPolicy(d.Get("tag_policy").([]interface{}))
+		if err != nil {
+			return nil, diag.Errorf("expanding `tag_policy`: %+v", err)
+		}
+
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
@@ -317,10 +372,24 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 			Features:                    expandFeatures(d.Get("features").([]interface{})),
 			StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
+			DefaultTags:                 expandDefaultTags(d.Get("default_tags").([]interface{})),
+			IgnoreTags:                  expandIgnoreTags(d.Get("ignore_tags").([]interface{})),
+			TagPolicy:                   *tagPolicy,
+			Retry:                       expandRetry(d.Get("retry").([]interface{})),
+			RateLimit:                   expandRateLimit(d.Get("rate_limit").([]interface{})),
+			ReadCache:                   expandReadCache(d.Get("read_cache").([]interface{})),
 
 			// this field is intentionally not exposed in the provider block, since it's only used for
 			// platform level tracing
 			CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
+
+			// the summary of the requests sent to Azure Resource Manager is rewritten after each operation, since
+			// the Provider isn't notified when a run completes
+			TelemetrySummaryFile: os.Getenv("ARM_PROVIDER_TELEMETRY_SUMMARY_FILE"),
+
+			// these fields are only used by the Acceptance Tests, to record and replay requests
+			CustomSender:       sender,
+			SkipAuthentication: skipAuthentication,
 		}
 
 		//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
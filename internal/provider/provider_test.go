@@ -5,6 +5,8 @@ import (
 	"log"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
 func TestProvider(t *testing.T) {
@@ -87,6 +89,22 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 	}
 }
 
+func TestTypedResourcesModelObjectsMatchSchema(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			resourceType := resource.ResourceType()
+			t.Run(fmt.Sprintf("Resource/%s", resourceType), func(t *testing.T) {
+				t.Logf("[DEBUG] Testing Resource %q..", resourceType)
+
+				wrapper := sdk.NewResourceWrapper(resource)
+				if err := wrapper.ValidateModelObjectAgainstSchema(); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			})
+		}
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	_ = AzureProvider()
 }
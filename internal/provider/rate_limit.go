@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaRateLimit() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"reads_per_second": {
+					Type:         pluginsdk.TypeFloat,
+					Optional:     true,
+					Default:      25.0,
+					ValidateFunc: validation.FloatBetween(0.1, 1000),
+				},
+
+				"writes_per_second": {
+					Type:         pluginsdk.TypeFloat,
+					Optional:     true,
+					Default:      10.0,
+					ValidateFunc: validation.FloatBetween(0.1, 1000),
+				},
+
+				"burst": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      50,
+					ValidateFunc: validation.IntBetween(1, 1000),
+				},
+
+				"adaptive": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+			},
+		},
+	}
+}
+
+// expandRateLimit returns the RateLimitOptions from the `rate_limit` block - requests are only rate limited
+// when the block is specified
+func expandRateLimit(input []interface{}) common.RateLimitOptions {
+	if len(input) == 0 || input[0] == nil {
+		return common.RateLimitOptions{}
+	}
+
+	raw := input[0].(map[string]interface{})
+	return common.RateLimitOptions{
+		Enabled:         true,
+		ReadsPerSecond:  raw["reads_per_second"].(float64),
+		WritesPerSecond: raw["writes_per_second"].(float64),
+		Burst:           raw["burst"].(int),
+		Adaptive:        raw["adaptive"].(bool),
+	}
+}
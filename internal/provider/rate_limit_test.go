@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+func TestExpandRateLimit(t *testing.T) {
+	if options := expandRateLimit(nil); options.Enabled {
+		t.Fatalf("expected rate limiting to be disabled when the block is omitted but got %+v", options)
+	}
+
+	options := expandRateLimit([]interface{}{
+		map[string]interface{}{
+			"reads_per_second":  12.5,
+			"writes_per_second": 2.0,
+			"burst":             20,
+			"adaptive":          false,
+		},
+	})
+	expected := common.RateLimitOptions{
+		Enabled:         true,
+		ReadsPerSecond:  12.5,
+		WritesPerSecond: 2,
+		Burst:           20,
+		Adaptive:        false,
+	}
+	if !reflect.DeepEqual(options, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, options)
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaReadCache() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_entries": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      10000,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// expandReadCache returns the ReadCacheOptions from the `read_cache` block - responses are only cached
+// when the block is specified
+func expandReadCache(input []interface{}) common.ReadCacheOptions {
+	if len(input) == 0 {
+		return common.ReadCacheOptions{}
+	}
+
+	// the block has no required fields, so is nil when specified without any
+	options := common.ReadCacheOptions{
+		Enabled:    true,
+		MaxEntries: 10000,
+	}
+	if raw, ok := input[0].(map[string]interface{}); ok {
+		options.MaxEntries = raw["max_entries"].(int)
+	}
+	return options
+}
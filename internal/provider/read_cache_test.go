@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+func TestExpandReadCache(t *testing.T) {
+	if options := expandReadCache(nil); options.Enabled {
+		t.Fatalf("expected caching to be disabled when the block is omitted but got %+v", options)
+	}
+
+	testData := []struct {
+		input    []interface{}
+		expected common.ReadCacheOptions
+	}{
+		{
+			// an empty block
+			input: []interface{}{nil},
+			expected: common.ReadCacheOptions{
+				Enabled:    true,
+				MaxEntries: 10000,
+			},
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"max_entries": 500,
+				},
+			},
+			expected: common.ReadCacheOptions{
+				Enabled:    true,
+				MaxEntries: 500,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		if actual := expandReadCache(v.input); !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
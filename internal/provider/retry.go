@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+const (
+	defaultRetryMaxAttempts         = 3
+	defaultRetryMaxBackoffInSeconds = 60
+)
+
+func schemaRetry() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_attempts": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryMaxAttempts,
+					ValidateFunc: validation.IntBetween(1, 20),
+				},
+
+				"max_backoff_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryMaxBackoffInSeconds,
+					ValidateFunc: validation.IntBetween(1, 600),
+				},
+
+				"use_retry_after_header": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"retryable_error_codes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandRetry returns the RetryOptions from the `retry` block, which are disabled when the block is omitted
+func expandRetry(input []interface{}) common.RetryOptions {
+	if len(input) == 0 || input[0] == nil {
+		return common.RetryOptions{}
+	}
+
+	raw := input[0].(map[string]interface{})
+	options := common.RetryOptions{
+		MaxAttempts:         raw["max_attempts"].(int),
+		MaxBackoff:          time.Duration(raw["max_backoff_in_seconds"].(int)) * time.Second,
+		UseRetryAfterHeader: raw["use_retry_after_header"].(bool),
+		ErrorCodes:          common.DefaultRetryableErrorCodes,
+	}
+	if v := *utils.ExpandStringSlice(raw["retryable_error_codes"].(*pluginsdk.Set).List()); len(v) > 0 {
+		options.ErrorCodes = v
+	}
+
+	return options
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandRetry(t *testing.T) {
+	options := expandRetry(nil)
+	if !reflect.DeepEqual(options, common.RetryOptions{}) || options.Configured() {
+		t.Fatalf("expected retries to be disabled when the block is omitted but got %+v", options)
+	}
+
+	options = expandRetry([]interface{}{
+		map[string]interface{}{
+			"max_attempts":           5,
+			"max_backoff_in_seconds": 30,
+			"use_retry_after_header": false,
+			"retryable_error_codes":  pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"ReferencedResourceNotProvisioned"}),
+		},
+	})
+	expected := common.RetryOptions{
+		MaxAttempts:         5,
+		MaxBackoff:          30 * time.Second,
+		UseRetryAfterHeader: false,
+		ErrorCodes:          []string{"ReferencedResourceNotProvisioned"},
+	}
+	if !reflect.DeepEqual(options, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, options)
+	}
+
+	options = expandRetry([]interface{}{
+		map[string]interface{}{
+			"max_attempts":           1,
+			"max_backoff_in_seconds": 60,
+			"use_retry_after_header": true,
+			"retryable_error_codes":  pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+		},
+	})
+	if options.Enabled() {
+		t.Fatalf("expected retries to be disabled when `max_attempts` is 1")
+	}
+	if !reflect.DeepEqual(options.ErrorCodes, common.DefaultRetryableErrorCodes) {
+		t.Fatalf("expected the default error codes when none are specified but got %+v", options.ErrorCodes)
+	}
+}
@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func schemaTagPolicy() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"required_keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"forbidden_keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"allowed_values": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: validateTagPolicyAllowedValues,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateTagPolicyAllowedValues(v interface{}, _ string) (warnings []string, errors []error) {
+	for key, expression := range v.(map[string]interface{}) {
+		if _, err := regexp.Compile(expression.(string)); err != nil {
+			errors = append(errors, fmt.Errorf("the pattern for the tag %q isn't a valid regular expression: %+v", key, err))
+		}
+	}
+
+	return
+}
+
+func expandTagPolicy(input []interface{}) (*tags.Policy, error) {
+	policy := tags.Policy{}
+	if len(input) == 0 || input[0] == nil {
+		return &policy, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	policy.RequiredKeys = *utils.ExpandStringSlice(raw["required_keys"].(*pluginsdk.Set).List())
+	policy.ForbiddenKeys = *utils.ExpandStringSlice(raw["forbidden_keys"].(*pluginsdk.Set).List())
+
+	policy.AllowedValues = make(map[string]*regexp.Regexp)
+	for key, expression := range raw["allowed_values"].(map[string]interface{}) {
+		compiled, err := regexp.Compile(expression.(string))
+		if err != nil {
+			return nil, fmt.Errorf("compiling the pattern for the tag %q: %+v", key, err)
+		}
+		policy.AllowedValues[key] = compiled
+	}
+
+	return &policy, nil
+}
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandIgnoreTags(input []interface{}) tags.IgnoreConfiguration {
+	if len(input) == 0 || input[0] == nil {
+		return tags.IgnoreConfiguration{}
+	}
+
+	raw := input[0].(map[string]interface{})
+	return tags.IgnoreConfiguration{
+		Keys:        *utils.ExpandStringSlice(raw["keys"].(*pluginsdk.Set).List()),
+		KeyPrefixes: *utils.ExpandStringSlice(raw["key_prefixes"].(*pluginsdk.Set).List()),
+	}
+}
+
+func expandDefaultTags(input []interface{}) map[string]string {
+	output := make(map[string]string)
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	for k, v := range raw["tags"].(map[string]interface{}) {
+		value, _ := tags.TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+// resourceSupportsTags returns whether the specified Resource exposes a configurable `tags` field
+// (e.g. via `tags.Schema()` or `tags.ForceNewSchema()`)
+func resourceSupportsTags(resource *pluginsdk.Resource) bool {
+	v, ok := resource.Schema["tags"]
+	return ok && v.Type == pluginsdk.TypeMap && v.Optional
+}
+
+// withProviderTags applies the `default_tags`, `ignore_tags` and `tag_policy` defined in the Provider block to the
+// specified Resource:
+//
+//   - at plan time the effective Tags (the `default_tags` overridden by the Tags defined on the Resource)
+//     are checked against the `tag_policy` and exposed in the computed `tags_all` field.
+//   - when the Resource is created/updated, the `tags` field contains the effective Tags - such that
+//     these are included in the output of `tags.Expand`.
+//   - when the Resource is read, any default Tags are stripped from the `tags` field (so that these don't
+//     show as a diff) - with the Tags returned from Azure exposed in the `tags_all` field.
+//   - Tags matching the `ignore_tags` block are neither read into the state, nor removed when the
+//     Resource is updated.
+//
+// The schema and CustomizeDiff for the Resource are updated in-place, with the returned wrapper applying
+// the Tags when the Resource is created, read or updated.
+func withProviderTags(resourceType string, resource *pluginsdk.Resource) resourceFuncWrapper {
+	resource.Schema["tags_all"] = tags.SchemaTagsAll()
+	forceNew := resource.Schema["tags"].ForceNew
+
+	// the Tags which exist on the Resource are retrieved using the Resource's own Read function
+	read := readFunction(resource)
+
+	customizeDiff := func(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || client == nil {
+			return nil
+		}
+
+		config := diff.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		tagsMap, known := configuredTags(config)
+		if !known {
+			// the Tags will be checked once they're known
+			return diff.SetNewComputed("tags_all")
+		}
+
+		effective := effectiveTags(client.DefaultTags, tagsMap)
+		for k := range effective {
+			if client.IgnoreTags.Ignored(k) {
+				delete(effective, k)
+			}
+		}
+
+		if !client.TagPolicy.IsEmpty() {
+			violations := client.TagPolicy.Violations(effective)
+			if err := client.TagPolicyReport.Record(policyResourceAddress(resourceType, diff.Id(), config), violations); err != nil {
+				return err
+			}
+		}
+
+		for _, v := range effective {
+			if v == nil {
+				// the values for some of the Tags aren't known until apply
+				return diff.SetNewComputed("tags_all")
+			}
+		}
+
+		if err := diff.SetNew("tags_all", tags.Flatten(effective)); err != nil {
+			return fmt.Errorf("setting `tags_all`: %+v", err)
+		}
+
+		// a change to the `default_tags` requires recreating Resources which can't update their Tags
+		if forceNew && diff.Id() != "" && diff.HasChange("tags_all") {
+			return diff.ForceNew("tags_all")
+		}
+
+		return nil
+	}
+
+	if existing := resource.CustomizeDiff; existing != nil {
+		resource.CustomizeDiff = pluginsdk.CustomDiffWithAll(existing, customizeDiff)
+	} else {
+		resource.CustomizeDiff = pluginsdk.CustomizeDiffShim(customizeDiff)
+	}
+
+	return func(operation string, inner resourceFunc) resourceFunc {
+		switch operation {
+		case "create", "update":
+			return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+				keep := applyProviderTags(ctx, d, meta, existingTags(resource, read))
+				diags := inner(ctx, d, meta)
+				flattenProviderTags(d, meta, keep)
+				return diags
+			}
+
+		case "read":
+			return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+				// the Tags previously stored in the state are those which were defined on the Resource
+				keep := d.Get("tags").(map[string]interface{})
+				diags := inner(ctx, d, meta)
+				flattenProviderTags(d, meta, keep)
+				return diags
+			}
+		}
+
+		return inner
+	}
+}
+
+// existingTagsFunc returns the Tags which currently exist on a Resource
+type existingTagsFunc func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) (map[string]*string, error)
+
+// existingTags returns an existingTagsFunc which retrieves the Tags which currently exist on a Resource by calling
+// the Resource's own Read function against a copy of its state - so that (unlike the Tags API) this works for
+// Resources whose ID isn't a Resource Manager ID
+func existingTags(resource *pluginsdk.Resource, read resourceFunc) existingTagsFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) (map[string]*string, error) {
+		if read == nil {
+			return nil, fmt.Errorf("the Resource doesn't have a Read function")
+		}
+
+		existing := resource.Data(d.State())
+		if diags := read(ctx, existing, meta); diags.HasError() {
+			for _, v := range diags {
+				if v.Severity == diag.Error {
+					return nil, fmt.Errorf("%s: %s", v.Summary, v.Detail)
+				}
+			}
+		}
+
+		return tags.Expand(existing.Get("tags").(map[string]interface{})), nil
+	}
+}
+
+// applyProviderTags sets the `tags` field to the effective Tags for this Resource prior to it being
+// created or updated, returning the Tags which are defined on the Resource itself.
+//
+// When updating a Resource, any existing Tags which are ignored via the `ignore_tags` block are also
+// included, so that these aren't removed from the Resource.
+func applyProviderTags(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, existing existingTagsFunc) map[string]interface{} {
+	configured := d.Get("tags").(map[string]interface{})
+
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		return configured
+	}
+
+	var ignored map[string]interface{}
+	if d.Id() != "" && !client.IgnoreTags.IsEmpty() {
+		existingTags, err := existing(ctx, d, meta)
+		if err != nil {
+			log.Printf("[DEBUG] retrieving the existing Tags for %q, the ignored Tags won't be retained: %+v", d.Id(), err)
+		} else {
+			ignored = client.IgnoreTags.Retain(existingTags)
+		}
+	}
+
+	if len(client.DefaultTags) == 0 && len(ignored) == 0 {
+		return configured
+	}
+
+	effective := tags.MergeDefaults(client.DefaultTags, configured)
+	for k, v := range ignored {
+		if _, ok := effective[k]; !ok {
+			effective[k] = v
+		}
+	}
+
+	if err := d.Set("tags", effective); err != nil {
+		log.Printf("[DEBUG] setting the effective Tags for %q: %+v", d.Id(), err)
+	}
+
+	return configured
+}
+
+// flattenProviderTags exposes the Tags for this Resource in the `tags_all` field, removing any Tags which
+// are ignored via the `ignore_tags` block - and stripping any default Tags which weren't defined on the
+// Resource (in `keep`) from the `tags` field
+func flattenProviderTags(d *pluginsdk.ResourceData, meta interface{}, keep map[string]interface{}) {
+	if d.Id() == "" {
+		// the Resource has been removed
+		return
+	}
+
+	actual := d.Get("tags").(map[string]interface{})
+
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		client = &clients.Client{}
+	}
+
+	actual = client.IgnoreTags.Remove(actual)
+	if err := d.Set("tags_all", actual); err != nil {
+		log.Printf("[DEBUG] setting `tags_all` for %q: %+v", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tags.StripDefaults(client.DefaultTags, actual, keep)); err != nil {
+		log.Printf("[DEBUG] setting `tags` for %q: %+v", d.Id(), err)
+	}
+}
+
+// effectiveTags returns the `default_tags` overridden by the Tags defined on the Resource, where the
+// value for any Tag which isn't known yet is nil
+func effectiveTags(defaults map[string]string, tagsMap map[string]*string) map[string]*string {
+	configured := make(map[string]interface{}, len(tagsMap))
+	for k, v := range tagsMap {
+		configured[k] = v
+	}
+
+	output := make(map[string]*string)
+	for k, v := range tags.MergeDefaults(defaults, configured) {
+		switch value := v.(type) {
+		case string:
+			output[k] = &value
+		case *string:
+			output[k] = value
+		}
+	}
+
+	return output
+}
+
+// configuredTags returns the Tags defined in the configuration, where the value for any Tag which
+// isn't known yet is nil - and whether the Tags are known at all
+func configuredTags(config cty.Value) (map[string]*string, bool) {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("tags") {
+		return nil, false
+	}
+
+	raw := config.GetAttr("tags")
+	if !raw.IsKnown() {
+		return nil, false
+	}
+
+	output := make(map[string]*string)
+	if raw.IsNull() {
+		return output, true
+	}
+
+	for it := raw.ElementIterator(); it.Next(); {
+		k, v := it.Element()
+		if v.IsNull() || !v.IsKnown() {
+			output[k.AsString()] = nil
+			continue
+		}
+
+		value := v.AsString()
+		output[k.AsString()] = &value
+	}
+
+	return output, true
+}
+
+// policyResourceAddress returns the Resource ID used to report the `tag_policy` violations for a Resource - or for a
+// Resource which doesn't exist yet the Resource Type, Name and Resource Group, such that Resources with the same
+// Name in different Resource Groups are reported separately
+func policyResourceAddress(resourceType, id string, config cty.Value) string {
+	if id != "" {
+		return id
+	}
+
+	address := resourceType
+	if name := configuredString(config, "name"); name != "" {
+		address = fmt.Sprintf("%s %q", resourceType, name)
+	}
+	if resourceGroup := configuredString(config, "resource_group_name"); resourceGroup != "" {
+		address = fmt.Sprintf("%s (Resource Group %q)", address, resourceGroup)
+	}
+	return address
+}
+
+// configuredString returns the value of the specified (string) field in the configuration for this Resource,
+// if it's known
+func configuredString(config cty.Value, key string) string {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		return ""
+	}
+
+	v := config.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() || v.Type() != cty.String {
+		return ""
+	}
+
+	return v.AsString()
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandTagPolicy(t *testing.T) {
+	policy, err := expandTagPolicy(nil)
+	if err != nil {
+		t.Fatalf("expanding an empty policy: %+v", err)
+	}
+	if !policy.IsEmpty() {
+		t.Fatalf("expected an empty policy when the block is omitted")
+	}
+
+	policy, err = expandTagPolicy([]interface{}{
+		map[string]interface{}{
+			"required_keys":  pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"owner"}),
+			"forbidden_keys": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"legacy"}),
+			"allowed_values": map[string]interface{}{
+				"environment": "^(dev|prod)$",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expanding the policy: %+v", err)
+	}
+	if len(policy.RequiredKeys) != 1 || len(policy.ForbiddenKeys) != 1 || len(policy.AllowedValues) != 1 {
+		t.Fatalf("expected one of each rule but got %+v", policy)
+	}
+	if !policy.AllowedValues["environment"].MatchString("prod") {
+		t.Fatalf("expected the pattern for `environment` to match `prod`")
+	}
+
+	if _, errs := validateTagPolicyAllowedValues(map[string]interface{}{"environment": "(dev"}, "allowed_values"); len(errs) != 1 {
+		t.Fatalf("expected an invalid regular expression to be rejected but got %+v", errs)
+	}
+}
+
+func TestConfiguredTags(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("example"),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"owner":       cty.StringVal("platform"),
+			"environment": cty.UnknownVal(cty.String),
+		}),
+	})
+
+	tagsMap, known := configuredTags(config)
+	if !known {
+		t.Fatalf("expected the tags to be known")
+	}
+	if v := tagsMap["owner"]; v == nil || *v != "platform" {
+		t.Fatalf("expected the tag `owner` to be `platform` but got %+v", v)
+	}
+	if v, ok := tagsMap["environment"]; !ok || v != nil {
+		t.Fatalf("expected the tag `environment` to be present with an unknown value but got %+v", v)
+	}
+	if address := policyResourceAddress("azurerm_resource_group", "", config); address != `azurerm_resource_group "example"` {
+		t.Fatalf("expected the address to be `azurerm_resource_group \"example\"` but got %q", address)
+	}
+	if address := policyResourceAddress("azurerm_resource_group", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example", config); address != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example" {
+		t.Fatalf("expected the Resource ID to be used for an existing resource but got %q", address)
+	}
+
+	unknown := cty.ObjectVal(map[string]cty.Value{
+		"tags": cty.UnknownVal(cty.Map(cty.String)),
+	})
+	if _, known := configuredTags(unknown); known {
+		t.Fatalf("expected the tags to be unknown")
+	}
+}
+
+func TestProviderTagsDefaultTags(t *testing.T) {
+	remote := make(map[string]*string)
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tags.Schema(),
+		},
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			remote = tags.Expand(d.Get("tags").(map[string]interface{}))
+			d.SetId("example")
+			return nil
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return tags.FlattenAndSet(d, remote)
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	wrapResourceFunctions(resource, withProviderTags("azurerm_example", resource))
+
+	client := &clients.Client{
+		DefaultTags: map[string]string{
+			"owner":       "platform",
+			"cost-centre": "1234",
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner":       "networking",
+			"environment": "prod",
+		},
+	})
+
+	if diags := resource.CreateContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+
+	expectedRemote := map[string]interface{}{
+		"owner":       "networking",
+		"cost-centre": "1234",
+		"environment": "prod",
+	}
+	if actual := tags.Flatten(remote); !reflect.DeepEqual(actual, expectedRemote) {
+		t.Fatalf("expected the tags sent to Azure to be %+v but got %+v", expectedRemote, actual)
+	}
+
+	if diags := resource.ReadContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	expectedTags := map[string]interface{}{
+		"owner":       "networking",
+		"environment": "prod",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expectedTags, actual)
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expectedRemote) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expectedRemote, actual)
+	}
+}
+
+func TestProviderTagsIgnoreTags(t *testing.T) {
+	value := func(input string) *string {
+		return &input
+	}
+	remote := map[string]*string{
+		"environment":      value("prod"),
+		"CreatedOnDate":    value("2022-01-01"),
+		"hidden-link:/abc": value("Resource"),
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tags.Schema(),
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return tags.FlattenAndSet(d, remote)
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	wrapResourceFunctions(resource, withProviderTags("azurerm_example", resource))
+
+	client := &clients.Client{
+		IgnoreTags: tags.IgnoreConfiguration{
+			Keys:        []string{"createdondate"},
+			KeyPrefixes: []string{"hidden-link:"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId("example")
+
+	if diags := resource.ReadContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"environment": "prod",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProviderTagsIgnoreTagsRetainedOnUpdate(t *testing.T) {
+	value := func(input string) *string {
+		return &input
+	}
+	remote := map[string]*string{
+		"environment":   value("prod"),
+		"CreatedOnDate": value("2022-01-01"),
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tags.Schema(),
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return tags.FlattenAndSet(d, remote)
+		},
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			remote = tags.Expand(d.Get("tags").(map[string]interface{}))
+			return nil
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	wrapResourceFunctions(resource, withProviderTags("azurerm_example", resource))
+
+	client := &clients.Client{
+		IgnoreTags: tags.IgnoreConfiguration{
+			Keys: []string{"createdondate"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"environment": "test",
+		},
+	})
+	d.SetId("example")
+
+	if diags := resource.UpdateContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("updating: %+v", diags)
+	}
+
+	expectedRemote := map[string]interface{}{
+		"environment":   "test",
+		"CreatedOnDate": "2022-01-01",
+	}
+	if actual := tags.Flatten(remote); !reflect.DeepEqual(actual, expectedRemote) {
+		t.Fatalf("expected the ignored tags to be retained when updating, such that the tags sent to Azure are %+v but got %+v", expectedRemote, actual)
+	}
+
+	expectedTags := map[string]interface{}{
+		"environment": "test",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expectedTags, actual)
+	}
+}
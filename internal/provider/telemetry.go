@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/telemetry"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// withTelemetry returns a wrapper for the Create, Read, Update and Delete functions for the specified Resource, such
+// that the requests sent to Azure Resource Manager during each operation are recorded - and then summarised once
+// the operation completes.
+func withTelemetry(resourceType string) resourceFuncWrapper {
+	return func(operation string, inner resourceFunc) resourceFunc {
+		return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			scope := telemetry.NewScope(resourceType, operation)
+			id := d.Id()
+
+			client, ok := meta.(*clients.Client)
+			if !ok || client == nil {
+				return inner(ctx, d, meta)
+			}
+
+			// most resources derive the context for requests from the Provider's StopContext (using the `timeouts`
+			// package) rather than the context passed in here - which is scoped using the ResourceData
+			done := telemetry.WithOperation(d, scope)
+			defer done()
+
+			diags := inner(telemetry.WithScope(ctx, scope), d, meta)
+
+			if v := d.Id(); v != "" {
+				id = v
+			}
+			client.Telemetry.Complete(scope, id)
+
+			return diags
+		}
+	}
+}
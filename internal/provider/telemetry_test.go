@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/telemetry"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func TestProviderTelemetryScopes(t *testing.T) {
+	var scopes []*telemetry.Scope
+	client := &clients.Client{
+		StopContext: context.TODO(),
+		Telemetry:   telemetry.NewRecorder(""),
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+		},
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			if meta != interface{}(client) {
+				t.Fatalf("expected the Provider's Client to be passed to the resource")
+			}
+
+			// most resources derive the context from the Provider's StopContext
+			ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+			defer cancel()
+
+			scopes = append(scopes, telemetry.FromContext(ctx))
+			d.SetId("example")
+			return nil
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+			defer cancel()
+
+			scopes = append(scopes, telemetry.FromContext(ctx))
+			return nil
+		},
+	}
+	wrapResourceFunctions(resource, withTelemetry("azurerm_example"))
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "example",
+	})
+
+	if diags := resource.CreateContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if diags := resource.DeleteContext(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("deleting: %+v", diags)
+	}
+
+	if len(scopes) != 2 {
+		t.Fatalf("expected 2 scopes but got %d", len(scopes))
+	}
+	for i, operation := range []string{"create", "delete"} {
+		scope := scopes[i]
+		if scope == nil || scope.ResourceType != "azurerm_example" || scope.Operation != operation {
+			t.Fatalf("expected a scope for the %q operation against `azurerm_example` but got %+v", operation, scope)
+		}
+	}
+
+	if operations := client.Telemetry.RunSummary().Total.Operations; operations != 2 {
+		t.Fatalf("expected 2 operations in the run summary but got %d", operations)
+	}
+
+	// once the operation has completed the context derived for the resource is no longer scoped
+	ctx, cancel := timeouts.ForRead(client.StopContext, d)
+	defer cancel()
+	if telemetry.FromContext(ctx) != nil {
+		t.Fatalf("expected no scope outside of an operation")
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// resourceFunc is a (context-aware) Create, Read, Update or Delete function for a Resource
+type resourceFunc = func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics
+
+// resourceFuncWrapper wraps the function for the specified operation (`create`, `read`, `update` or `delete`)
+type resourceFuncWrapper func(operation string, inner resourceFunc) resourceFunc
+
+// wrapResourceFunctions wraps the Create, Read, Update and Delete functions for the specified Resource (regardless
+// of which variant of each function is implemented) with each of the specified wrappers, where the first wrapper
+// is the outermost - this is the single place where the Provider wraps the functions for each Resource.
+func wrapResourceFunctions(resource *pluginsdk.Resource, wrappers ...resourceFuncWrapper) {
+	if len(wrappers) == 0 {
+		return
+	}
+
+	wrap := func(operation string, inner resourceFunc) resourceFunc {
+		for i := len(wrappers) - 1; i >= 0; i-- {
+			inner = wrappers[i](operation, inner)
+		}
+		return inner
+	}
+
+	//nolint:staticcheck
+	if resource.Create != nil {
+		resource.CreateContext = wrap("create", withoutContext(resource.Create))
+		resource.Create = nil
+	} else if resource.CreateContext != nil {
+		resource.CreateContext = wrap("create", resource.CreateContext)
+	}
+	if resource.CreateWithoutTimeout != nil {
+		resource.CreateWithoutTimeout = wrap("create", resource.CreateWithoutTimeout)
+	}
+
+	//nolint:staticcheck
+	if resource.Read != nil {
+		resource.ReadContext = wrap("read", withoutContext(resource.Read))
+		resource.Read = nil
+	} else if resource.ReadContext != nil {
+		resource.ReadContext = wrap("read", resource.ReadContext)
+	}
+	if resource.ReadWithoutTimeout != nil {
+		resource.ReadWithoutTimeout = wrap("read", resource.ReadWithoutTimeout)
+	}
+
+	//nolint:staticcheck
+	if resource.Update != nil {
+		resource.UpdateContext = wrap("update", withoutContext(resource.Update))
+		resource.Update = nil
+	} else if resource.UpdateContext != nil {
+		resource.UpdateContext = wrap("update", resource.UpdateContext)
+	}
+	if resource.UpdateWithoutTimeout != nil {
+		resource.UpdateWithoutTimeout = wrap("update", resource.UpdateWithoutTimeout)
+	}
+
+	//nolint:staticcheck
+	if resource.Delete != nil {
+		resource.DeleteContext = wrap("delete", withoutContext(resource.Delete))
+		resource.Delete = nil
+	} else if resource.DeleteContext != nil {
+		resource.DeleteContext = wrap("delete", resource.DeleteContext)
+	}
+	if resource.DeleteWithoutTimeout != nil {
+		resource.DeleteWithoutTimeout = wrap("delete", resource.DeleteWithoutTimeout)
+	}
+}
+
+// readFunction returns the (context-aware) Read function for the specified Resource, or nil if it doesn't have one
+func readFunction(resource *pluginsdk.Resource) resourceFunc {
+	//nolint:staticcheck
+	switch {
+	case resource.Read != nil:
+		return withoutContext(resource.Read)
+	case resource.ReadContext != nil:
+		return resource.ReadContext
+	case resource.ReadWithoutTimeout != nil:
+		return resource.ReadWithoutTimeout
+	}
+
+	return nil
+}
+
+func withoutContext(inner func(d *pluginsdk.ResourceData, meta interface{}) error) resourceFunc {
+	return func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(inner(d, meta))
+	}
+}
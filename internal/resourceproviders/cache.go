@@ -11,13 +11,14 @@ import (
 var cachedResourceProviders *[]string
 
 // CacheSupportedProviders attempts to retrieve the supported Resource Providers from the Resource Manager API
-// and caches them, for used in enhanced validation
-func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersClient) {
+// and caches them, for used in enhanced validation - returning whether these were retrieved
+func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersClient) bool {
 	providers, err := availableResourceProviders(ctx, client)
 	if err != nil {
 		log.Printf("[DEBUG] error retrieving providers: %s. Enhanced validation will be unavailable", err)
-		return
+		return false
 	}
 
 	cachedResourceProviders = providers
+	return true
 }
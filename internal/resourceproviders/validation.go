@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/catalogue"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
@@ -15,15 +16,37 @@ var enhancedEnabled = features.EnhancedValidationEnabled()
 // against the list of Resource Provider supported by this Azure Environment.
 //
 // NOTE: this is best-effort - if the users offline, or the API doesn't return it we'll
-// fall back to the original approach
+// fall back to the original approach, warning when the value isn't present in the offline
+// Catalogue for this Azure Environment. This is a warning rather than an error since the
+// Catalogue is a snapshot taken when the Provider was released, so a Resource Provider
+// which has since been added to Azure isn't present in it - whereas a Resource Provider
+// which isn't returned from the Azure API is an error.
 func EnhancedValidate(i interface{}, k string) ([]string, []error) {
-	if !enhancedEnabled || cachedResourceProviders == nil {
+	if !enhancedEnabled {
 		return validation.StringIsNotEmpty(i, k)
 	}
 
+	if cachedResourceProviders == nil {
+		return catalogueValidation(i, k, catalogue.Current())
+	}
+
 	return enhancedValidation(i, k)
 }
 
+func catalogueValidation(i interface{}, k string, c *catalogue.Catalogue) ([]string, []error) {
+	warnings, errors := validation.StringIsNotEmpty(i, k)
+	if len(errors) > 0 || c == nil {
+		return warnings, errors
+	}
+
+	v := i.(string)
+	if !c.HasResourceProvider(v) {
+		warnings = append(warnings, fmt.Sprintf("%q was not found in the list of Resource Providers for the %q Azure Environment (from the offline catalogue version %q, which may be out of date): %q", v, c.Environment, c.Version, strings.Join(c.ResourceProviders, ", ")))
+	}
+
+	return warnings, nil
+}
+
 func enhancedValidation(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
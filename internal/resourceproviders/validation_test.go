@@ -84,3 +84,48 @@ func TestEnhancedValidationEnabled(t *testing.T) {
 		}
 	}
 }
+
+func TestEnhancedValidationOfflineCatalogue(t *testing.T) {
+	testCases := []struct {
+		input   string
+		valid   bool
+		warning bool
+	}{
+		{
+			input: "",
+			valid: false,
+		},
+		{
+			input:   "micr0soft",
+			valid:   true,
+			warning: true,
+		},
+		{
+			input: "Microsoft.Compute",
+			valid: true,
+		},
+		{
+			input:   "Microsoft.Comptue",
+			valid:   true,
+			warning: true,
+		},
+	}
+	enhancedEnabled = true
+	cachedResourceProviders = nil
+	defer func() {
+		enhancedEnabled = features.EnhancedValidationEnabled()
+	}()
+
+	for _, testCase := range testCases {
+		t.Logf("Testing %q..", testCase.input)
+
+		// a value missing from the offline catalogue is only a warning, since the catalogue may be out of date
+		warnings, errors := EnhancedValidate(testCase.input, "name")
+		if valid := len(errors) == 0; testCase.valid != valid {
+			t.Errorf("Expected %t but got %t", testCase.valid, valid)
+		}
+		if warning := len(warnings) > 0; testCase.warning != warning {
+			t.Errorf("Expected a warning to be %t but got %t", testCase.warning, warning)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+var _ Logger = &JSONLogger{}
+
+// JSONLogger provides a Logger implementation which writes each log message as a single
+// JSON line - containing the Resource Type, Resource ID, Operation, Correlation Request ID
+// and the time elapsed since the Operation started - so that the Provider logs can be
+// shipped to a log pipeline.
+//
+// Warnings are also passed to the wrapped Logger, so that these continue to be surfaced
+// to users as Diagnostics.
+type JSONLogger struct {
+	// ResourceType is the Terraform Resource Type, e.g. `azurerm_resource_group`
+	ResourceType string
+
+	// Operation is the operation being performed, e.g. `create` or `read`
+	Operation string
+
+	// CorrelationRequestID is the value sent in the `x-ms-correlation-request-id` header
+	CorrelationRequestID string
+
+	resourceData *schema.ResourceData
+	started      time.Time
+	warnings     Logger
+	writer       io.Writer
+}
+
+// jsonLogEntry is a single line written by the JSONLogger
+type jsonLogEntry struct {
+	Timestamp            string `json:"timestamp"`
+	Level                string `json:"level"`
+	Message              string `json:"message"`
+	ResourceType         string `json:"resource_type"`
+	ResourceID           string `json:"resource_id,omitempty"`
+	Operation            string `json:"operation"`
+	CorrelationRequestID string `json:"correlation_request_id,omitempty"`
+	ElapsedMs            int64  `json:"elapsed_ms"`
+}
+
+// jsonLogOutput is the writer shared by each JSONLogger, which is opened the first time it's used
+var jsonLogOutput struct {
+	once   sync.Once
+	writer io.Writer
+}
+
+// NewJSONLogger returns a JSONLogger for the specified Operation, which writes to the file specified in
+// `ARM_PROVIDER_LOG_FILE` (or otherwise stderr) - rather than to the standard logger, whose output is
+// prefixed by Terraform and as such isn't valid JSON. The Resource ID is read from the ResourceData (when specified) as each message is
+// written, so that this is available once it's been set during a Create.
+func NewJSONLogger(resourceType, operation, correlationRequestID string, d *schema.ResourceData, warnings Logger) *JSONLogger {
+	if warnings == nil {
+		warnings = NullLogger{}
+	}
+
+	return &JSONLogger{
+		ResourceType:         resourceType,
+		Operation:            operation,
+		CorrelationRequestID: correlationRequestID,
+		resourceData:         d,
+		started:              time.Now(),
+		warnings:             warnings,
+		writer:               jsonLogWriter(),
+	}
+}
+
+// Info writes out a message with the level `INFO` verbatim
+func (l *JSONLogger) Info(message string) {
+	l.write("INFO", message)
+}
+
+// Infof writes out a message with the level `INFO` formatted
+// with the specified arguments
+func (l *JSONLogger) Infof(format string, args ...interface{}) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+// Warn writes out a message with the level `WARN` verbatim
+func (l *JSONLogger) Warn(message string) {
+	l.write("WARN", message)
+	l.warnings.Warn(message)
+}
+
+// Warnf writes out a message with the level `WARN` formatted
+// with the specified arguments
+func (l *JSONLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *JSONLogger) write(level, message string) {
+	now := time.Now()
+	entry := jsonLogEntry{
+		Timestamp:            now.UTC().Format(time.RFC3339Nano),
+		Level:                level,
+		Message:              message,
+		ResourceType:         l.ResourceType,
+		Operation:            l.Operation,
+		CorrelationRequestID: l.CorrelationRequestID,
+		ElapsedMs:            now.Sub(l.started).Milliseconds(),
+	}
+	if l.resourceData != nil {
+		entry.ResourceID = l.resourceData.Id()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] marshalling the log message %q as JSON: %+v", message, err)
+		return
+	}
+
+	// the output is written directly rather than via the standard logger, so that each line is valid JSON
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		log.Printf("[WARN] writing the log message %q: %+v", message, err)
+	}
+}
+
+// jsonLogWriter returns the writer shared by each JSONLogger
+func jsonLogWriter() io.Writer {
+	jsonLogOutput.once.Do(func() {
+		jsonLogOutput.writer = newJSONLogWriter(features.StructuredLoggingFile())
+	})
+	return jsonLogOutput.writer
+}
+
+// newJSONLogWriter returns a writer which appends to the specified file - or writes to stderr when no file is
+// specified (or it can't be opened). Each line is written using a single call to Write, which is serialized so
+// that lines written by resources being provisioned in parallel aren't interleaved.
+func newJSONLogWriter(path string) io.Writer {
+	var writer io.Writer = os.Stderr
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Printf("[WARN] opening the log file %q, writing to stderr instead: %+v", path, err)
+		} else {
+			writer = file
+		}
+	}
+
+	return &lockedWriter{
+		writer: writer,
+	}
+}
+
+// lockedWriter serializes the calls to Write for the wrapped writer
+type lockedWriter struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.writer.Write(p)
+}
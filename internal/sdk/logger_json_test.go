@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestJSONLogger(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}, map[string]interface{}{})
+	diagnostics := &DiagnosticsLogger{}
+	buf := bytes.NewBuffer(nil)
+
+	logger := NewJSONLogger("azurerm_example", "create", "11111111-1111-1111-1111-111111111111", d, diagnostics)
+	logger.writer = buf
+
+	logger.Infof("creating %s..", "example")
+	d.SetId("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+	logger.Warn("something to be aware of")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines but got %d: %q", len(lines), buf.String())
+	}
+
+	entries := make([]jsonLogEntry, 0)
+	for _, line := range lines {
+		var entry jsonLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unmarshalling %q: %+v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if entries[0].Level != "INFO" || entries[0].Message != "creating example.." || entries[0].ResourceID != "" {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Level != "WARN" || entries[1].ResourceID != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example" {
+		t.Fatalf("unexpected second entry: %+v", entries[1])
+	}
+	for _, entry := range entries {
+		if entry.ResourceType != "azurerm_example" || entry.Operation != "create" || entry.CorrelationRequestID != "11111111-1111-1111-1111-111111111111" {
+			t.Fatalf("expected the entry to contain the resource type, operation and correlation request id but got %+v", entry)
+		}
+		if entry.ElapsedMs < 0 {
+			t.Fatalf("expected a non-negative elapsed time but got %d", entry.ElapsedMs)
+		}
+	}
+
+	if len(diagnostics.diagnostics) != 1 || diagnostics.diagnostics[0].Summary != "something to be aware of" {
+		t.Fatalf("expected the warning to be passed to the wrapped logger but got %+v", diagnostics.diagnostics)
+	}
+}
+
+func TestJSONLoggerFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.log")
+	writer := newJSONLogWriter(path)
+
+	// loggers for resources being provisioned in parallel share the same writer
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			logger := NewJSONLogger("azurerm_example", "read", "", nil, nil)
+			logger.writer = writer
+			for j := 0; j < 10; j++ {
+				logger.Infof("message %d from logger %d with \"quotes\"\nand a new line", j, i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %q: %+v", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("expected 100 lines but got %d", len(lines))
+	}
+
+	messages := make(map[string]struct{})
+	for _, line := range lines {
+		var entry jsonLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("expected each line to be valid JSON but unmarshalling %q: %+v", line, err)
+		}
+		messages[entry.Message] = struct{}{}
+	}
+
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			message := fmt.Sprintf("message %d from logger %d with \"quotes\"\nand a new line", j, i)
+			if _, ok := messages[message]; !ok {
+				t.Fatalf("expected the message %q to be logged", message)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// modelFieldTag is the parsed representation of the `tfschema` struct tag on a field within
+// a model object, which is in the format `tfschema:"name[,option]"` - for example
+// `tfschema:"nickname,omitempty"`
+type modelFieldTag struct {
+	// name is the key for this field in the Terraform Schema
+	name string
+
+	// omitEmpty specifies that this field shouldn't be Encoded into the Terraform State when it's
+	// the zero value for its type (e.g. a nil pointer or an empty string) - which allows the
+	// existing value in the State to be retained
+	omitEmpty bool
+}
+
+// parseModelFieldTag parses the `tfschema` struct tag for the specified field, returning nil
+// if the field doesn't have a `tfschema` struct tag
+func parseModelFieldTag(field reflect.StructField) (*modelFieldTag, error) {
+	raw, exists := field.Tag.Lookup("tfschema")
+	if !exists {
+		return nil, nil
+	}
+
+	segments := strings.Split(raw, ",")
+	tag := modelFieldTag{
+		name: segments[0],
+	}
+	if tag.name == "" {
+		return nil, fmt.Errorf("the `tfschema` tag for the field %q is missing a name", field.Name)
+	}
+
+	for _, option := range segments[1:] {
+		switch option {
+		case "omitempty":
+			tag.omitEmpty = true
+
+		default:
+			return nil, fmt.Errorf("the `tfschema` tag for the field %q contains an unsupported option %q", field.Name, option)
+		}
+	}
+
+	return &tag, nil
+}
+
+// nestedPath returns the path to a nested field within the Terraform Schema, e.g. `block.0.field`
+func nestedPath(prefix string, segment interface{}) string {
+	if prefix == "" {
+		return fmt.Sprintf("%v", segment)
+	}
+
+	return fmt.Sprintf("%s.%v", prefix, segment)
+}
@@ -130,6 +130,19 @@ type ResourceWithCustomizeDiff interface {
 	CustomizeDiff() ResourceFunc
 }
 
+// ResourceWithSchemaKeysNotInModel is an optional interface
+//
+// Resources implementing this interface can specify the keys within the Schema which are intentionally
+// not present in the Model Object (for example, since these are get/set using the ResourceData directly),
+// which are otherwise reported when the Model Object is validated against the Schema.
+// Nested keys are specified in the same format as the State, e.g. `site_config.0.linux_fx_version`.
+type ResourceWithSchemaKeysNotInModel interface {
+	Resource
+
+	// SchemaKeysNotInModel returns the keys within the Schema which aren't present in the Model Object
+	SchemaKeysNotInModel() []string
+}
+
 // ResourceRunFunc is the function which can be run
 // ctx provides a Context instance with the user-provided timeout
 // metadata is a reference to an object containing the Client, ResourceData and a Logger
@@ -48,171 +48,259 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	}
 
 	objType := reflect.TypeOf(input).Elem()
+	objVal := reflect.ValueOf(input).Elem()
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("need a pointer to a struct but got a pointer to %s", objType.Kind())
+	}
+
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		debugLogger.Infof("Field", field)
 
-		if val, exists := field.Tag.Lookup("tfschema"); exists {
-			tfschemaValue, valExists := stateRetriever.GetOkExists(val)
-			if !valExists {
-				continue
-			}
+		tag, err := parseModelFieldTag(field)
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			continue
+		}
 
-			debugLogger.Infof("TFSchemaValue: ", tfschemaValue)
-			debugLogger.Infof("Input Type: ", reflect.ValueOf(input).Elem().Field(i).Type())
+		tfschemaValue, valExists := stateRetriever.GetOkExists(tag.name)
+		if !valExists {
+			// this allows pointer fields to remain nil when the value isn't set
+			continue
+		}
 
-			fieldName := reflect.ValueOf(input).Elem().Field(i).String()
-			if err := setValue(input, tfschemaValue, i, fieldName, debugLogger); err != nil {
-				return fmt.Errorf("while setting value %+v of model field %q: %+v", tfschemaValue, fieldName, err)
-			}
+		debugLogger.Infof("TFSchemaValue: ", tfschemaValue)
+		debugLogger.Infof("Input Type: ", objVal.Field(i).Type())
+
+		if err := decodeValue(tag.name, objVal.Field(i), tfschemaValue, debugLogger); err != nil {
+			return fmt.Errorf("while setting value %+v of model field %q: %+v", tfschemaValue, field.Name, err)
 		}
 	}
+
 	return nil
 }
 
-func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
-	debugLogger.Infof("setting list value for %q..", fieldName)
+// decodeValue decodes the value from the Terraform Schema at the specified path into the target,
+// recursing into any nested blocks
+func decodeValue(path string, target reflect.Value, value interface{}, debugLogger Logger) (errOut error) {
 	defer func() {
 		if r := recover(); r != nil {
-			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
-			out, ok := r.(error)
-			if !ok {
-				return
-			}
-
-			errOut = out
+			debugLogger.Warnf("error setting value for %q: %+v", path, r)
+			errOut = fmt.Errorf("setting value for %q: %+v", path, r)
 		}
 	}()
 
-	if v, ok := tfschemaValue.(string); ok {
-		debugLogger.Infof("[String] Decode %+v", v)
-		debugLogger.Infof("Input %+v", reflect.ValueOf(input))
-		debugLogger.Infof("Input Elem %+v", reflect.ValueOf(input).Elem())
-		reflect.ValueOf(input).Elem().Field(index).SetString(v)
+	if value == nil {
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	switch target.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(target.Type().Elem())
+		if _, isMap := value.(map[string]interface{}); !isMap && elem.Elem().Kind() == reflect.Struct {
+			// a single nested block (e.g. `MaxItems: 1`) remains nil when it's not specified
+			items, err := listItems(path, value)
+			if err != nil {
+				return err
+			}
+			if len(items) == 0 || items[0] == nil {
+				return nil
+			}
+		}
+
+		if err := decodeValue(path, elem.Elem(), value, debugLogger); err != nil {
+			return err
+		}
+		target.Set(elem)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(int32); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	case reflect.String:
+		v, ok := value.(string)
+		if !ok {
+			return typeMismatchError(path, value, target.Type())
+		}
+		debugLogger.Infof("[String] Decode %+v", v)
+		target.SetString(v)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(int64); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := value.(type) {
+		case int:
+			target.SetInt(int64(v))
+		case int32:
+			target.SetInt(int64(v))
+		case int64:
+			target.SetInt(v)
+		default:
+			return typeMismatchError(path, value, target.Type())
+		}
+		debugLogger.Infof("[INT] Decode %+v", value)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(float64); ok {
-		debugLogger.Infof("[Float] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetFloat(v)
+	case reflect.Float32, reflect.Float64:
+		switch v := value.(type) {
+		case float64:
+			target.SetFloat(v)
+		case float32:
+			target.SetFloat(float64(v))
+		case int:
+			target.SetFloat(float64(v))
+		default:
+			return typeMismatchError(path, value, target.Type())
+		}
+		debugLogger.Infof("[Float] Decode %+v", value)
 		return nil
-	}
 
-	// Doesn't work for empty bools?
-	if v, ok := tfschemaValue.(bool); ok {
+	case reflect.Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return typeMismatchError(path, value, target.Type())
+		}
 		debugLogger.Infof("[BOOL] Decode %+v", v)
+		target.SetBool(v)
+		return nil
 
-		reflect.ValueOf(input).Elem().Field(index).SetBool(v)
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(value))
 		return nil
+
+	case reflect.Map:
+		return decodeMapValue(path, target, value, debugLogger)
+
+	case reflect.Slice:
+		return decodeSliceValue(path, target, value, debugLogger)
+
+	case reflect.Struct:
+		return decodeStructValue(path, target, value, debugLogger)
 	}
 
-	if v, ok := tfschemaValue.(*schema.Set); ok {
-		return setListValue(input, index, fieldName, v.List(), debugLogger)
+	return fmt.Errorf("the type %s of the model field for %q is not supported", target.Type(), path)
+}
+
+func decodeMapValue(path string, target reflect.Value, value interface{}, debugLogger Logger) error {
+	mapConfig, ok := value.(map[string]interface{})
+	if !ok {
+		return typeMismatchError(path, value, target.Type())
+	}
+	if target.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("the model field for %q must be a map with string keys but got %s", path, target.Type())
 	}
 
-	if mapConfig, ok := tfschemaValue.(map[string]interface{}); ok {
-		mapOutput := reflect.MakeMap(reflect.ValueOf(input).Elem().Field(index).Type())
-		for key, val := range mapConfig {
-			mapOutput.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
+	mapOutput := reflect.MakeMapWithSize(target.Type(), len(mapConfig))
+	for key, val := range mapConfig {
+		elem := reflect.New(target.Type().Elem()).Elem()
+		if err := decodeValue(nestedPath(path, key), elem, val, debugLogger); err != nil {
+			return err
 		}
+		mapOutput.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elem)
+	}
 
-		reflect.ValueOf(input).Elem().Field(index).Set(mapOutput)
+	target.Set(mapOutput)
+	return nil
+}
+
+func decodeSliceValue(path string, target reflect.Value, value interface{}, debugLogger Logger) error {
+	items, err := listItems(path, value)
+	if err != nil {
+		return err
+	}
+	if items == nil {
+		// typed empty slices (which aren't returned from the Plugin SDK) are left as-is
 		return nil
 	}
 
-	if v, ok := tfschemaValue.([]interface{}); ok {
-		return setListValue(input, index, fieldName, v, debugLogger)
+	elemType := target.Type().Elem()
+	isBlock := elemType.Kind() == reflect.Struct || (elemType.Kind() == reflect.Ptr && elemType.Elem().Kind() == reflect.Struct)
+
+	output := reflect.MakeSlice(target.Type(), 0, len(items))
+	for i, item := range items {
+		if item == nil && isBlock {
+			continue
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := decodeValue(nestedPath(path, i), elem, item, debugLogger); err != nil {
+			return err
+		}
+		output = reflect.Append(output, elem)
 	}
 
+	target.Set(output)
 	return nil
 }
 
-func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
-	switch fieldType := reflect.ValueOf(input).Elem().Field(index).Type(); fieldType {
-	case reflect.TypeOf([]string{}):
-		stringSlice := reflect.MakeSlice(reflect.TypeOf([]string{}), len(v), len(v))
-		for i, stringVal := range v {
-			stringSlice.Index(i).SetString(stringVal.(string))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(stringSlice)
-
-	case reflect.TypeOf([]int{}):
-		iSlice := reflect.MakeSlice(reflect.TypeOf([]int{}), len(v), len(v))
-		for i, iVal := range v {
-			iSlice.Index(i).SetInt(int64(iVal.(int)))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(iSlice)
-
-	case reflect.TypeOf([]float64{}):
-		fSlice := reflect.MakeSlice(reflect.TypeOf([]float64{}), len(v), len(v))
-		for i, fVal := range v {
-			fSlice.Index(i).SetFloat(fVal.(float64))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(fSlice)
-
-	case reflect.TypeOf([]bool{}):
-		bSlice := reflect.MakeSlice(reflect.TypeOf([]bool{}), len(v), len(v))
-		for i, bVal := range v {
-			bSlice.Index(i).SetBool(bVal.(bool))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(bSlice)
-
-	default:
-		valueToSet := reflect.MakeSlice(reflect.ValueOf(input).Elem().Field(index).Type(), 0, 0)
-		debugLogger.Infof("List Type", valueToSet.Type())
-
-		for _, mapVal := range v {
-			if test, ok := mapVal.(map[string]interface{}); ok && test != nil {
-				elem := reflect.New(fieldType.Elem())
-				debugLogger.Infof("element ", elem)
-				for j := 0; j < elem.Type().Elem().NumField(); j++ {
-					nestedField := elem.Type().Elem().Field(j)
-					debugLogger.Infof("nestedField ", nestedField)
-
-					if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
-						nestedTFSchemaValue := test[val]
-						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, debugLogger); err != nil {
-							return err
-						}
-					}
-				}
-
-				if !elem.CanSet() {
-					elem = elem.Elem()
-				}
-
-				if valueToSet.Kind() == reflect.Ptr {
-					valueToSet.Elem().Set(reflect.Append(valueToSet.Elem(), elem))
-				} else {
-					valueToSet = reflect.Append(valueToSet, elem)
-				}
-
-				debugLogger.Infof("value to set type after changes", valueToSet.Type())
-			}
+func decodeStructValue(path string, target reflect.Value, value interface{}, debugLogger Logger) error {
+	// a single nested block (e.g. `MaxItems: 1`) can be decoded into a struct, rather than a slice
+	if _, ok := value.(map[string]interface{}); !ok {
+		items, err := listItems(path, value)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		if len(items) > 1 {
+			return fmt.Errorf("the model field for %q is a single struct but %d items were specified", path, len(items))
 		}
 
-		valueToSet = reflect.Indirect(valueToSet)
-		fieldToSet := reflect.ValueOf(input).Elem().Field(index)
-		fieldToSet.Set(reflect.Indirect(valueToSet))
+		return decodeStructValue(nestedPath(path, 0), target, items[0], debugLogger)
+	}
+
+	nested := value.(map[string]interface{})
+	for j := 0; j < target.NumField(); j++ {
+		nestedField := target.Type().Field(j)
+		debugLogger.Infof("nestedField ", nestedField)
+
+		tag, err := parseModelFieldTag(nestedField)
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			continue
+		}
+
+		nestedValue, ok := nested[tag.name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(nestedPath(path, tag.name), target.Field(j), nestedValue, debugLogger); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// listItems returns the items within a List or Set from the Terraform Schema - or nil for an empty
+// typed slice
+func listItems(path string, value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, nil
+
+	case *schema.Set:
+		return v.List(), nil
+	}
+
+	if reflect.TypeOf(value).Kind() == reflect.Slice {
+		sv := reflect.ValueOf(value)
+		if sv.Len() == 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			items[i] = sv.Index(i).Interface()
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("expected a list or set for %q but got %T", path, value)
+}
+
+func typeMismatchError(path string, value interface{}, target reflect.Type) error {
+	return fmt.Errorf("cannot decode the value %+v (%T) for %q into the model field of type %s", value, value, path, target)
+}
@@ -2,7 +2,10 @@ package sdk
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type decodeTestData struct {
@@ -698,6 +701,178 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceDecode_Sets(t *testing.T) {
+	type Inner struct {
+		Name string `tfschema:"name"`
+	}
+	type Type struct {
+		SetOfStrings []string `tfschema:"set_of_strings"`
+		SetOfBlocks  []Inner  `tfschema:"set_of_blocks"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"set_of_strings": schema.NewSet(schema.HashString, []interface{}{"hello"}),
+			"set_of_blocks": schema.NewSet(func(v interface{}) int {
+				return schema.HashString(v.(map[string]interface{})["name"])
+			}, []interface{}{
+				map[string]interface{}{
+					"name": "first",
+				},
+			}),
+		},
+		Input: &Type{},
+		Expected: &Type{
+			SetOfStrings: []string{"hello"},
+			SetOfBlocks: []Inner{
+				{
+					Name: "first",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_Pointers(t *testing.T) {
+	type Inner struct {
+		Value *int64 `tfschema:"value"`
+	}
+	type Type struct {
+		Name        *string  `tfschema:"name"`
+		Count       *int64   `tfschema:"count"`
+		Enabled     *bool    `tfschema:"enabled"`
+		Price       *float64 `tfschema:"price"`
+		Unset       *string  `tfschema:"unset"`
+		Single      *Inner   `tfschema:"single"`
+		EmptySingle *Inner   `tfschema:"empty_single"`
+		List        []*Inner `tfschema:"list"`
+	}
+	name := "hello"
+	count := int64(0)
+	enabled := false
+	price := float64(1.5)
+	value := int64(42)
+	decodeTestData{
+		State: map[string]interface{}{
+			"name":    "hello",
+			"count":   0,
+			"enabled": false,
+			"price":   1.5,
+			"single": []interface{}{
+				map[string]interface{}{
+					"value": 42,
+				},
+			},
+			"empty_single": []interface{}{},
+			"list": []interface{}{
+				map[string]interface{}{
+					"value": 42,
+				},
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Name:    &name,
+			Count:   &count,
+			Enabled: &enabled,
+			Price:   &price,
+			Single: &Inner{
+				Value: &value,
+			},
+			List: []*Inner{
+				{
+					Value: &value,
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_SingleNestedStruct(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		Single Inner `tfschema:"single"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"single": []interface{}{
+				map[string]interface{}{
+					"value": "hello",
+				},
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Single: Inner{
+				Value: "hello",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_MapOfInterfaces(t *testing.T) {
+	type Type struct {
+		Settings map[string]interface{} `tfschema:"settings"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"settings": map[string]interface{}{
+				"hello": "world",
+				"count": 3,
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Settings: map[string]interface{}{
+				"hello": "world",
+				"count": 3,
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_TagOptions(t *testing.T) {
+	type Type struct {
+		Name string `tfschema:"name,omitempty"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"name": "hello",
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Name: "hello",
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_TypeMismatchIncludesFieldPath(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		NestedObject []Inner `tfschema:"inner"`
+	}
+	state := testDataGetter{
+		values: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"value": true,
+				},
+			},
+		},
+	}
+
+	err := decodeReflectedType(&Type{}, state, ConsoleLogger{})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"inner.0.value"`) {
+		t.Fatalf("expected the error to contain the field path `inner.0.value` but got: %+v", err)
+	}
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
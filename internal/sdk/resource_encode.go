@@ -35,107 +35,154 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 	defer func() {
 		if r := recover(); r != nil {
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
-			out, ok := r.(error)
-			if !ok {
-				return
-			}
-
-			errOut = out
+			errOut = fmt.Errorf("serializing %q: %+v", fieldName, r)
 		}
 	}()
 
-	output = make(map[string]interface{})
+	return encodeStruct("", objType, objVal, debugLogger)
+}
+
+// encodeStruct encodes each of the fields within the specified struct (which is located at the specified
+// path within the Terraform Schema) containing a `tfschema` struct tag
+func encodeStruct(path string, objType reflect.Type, objVal reflect.Value, debugLogger Logger) (map[string]interface{}, error) {
+	output := make(map[string]interface{})
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
-		if tfschemaTag, exists := field.Tag.Lookup("tfschema"); exists {
-			switch field.Type.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				iv := fieldVal.Int()
-				debugLogger.Infof("Setting %q to %d", tfschemaTag, iv)
-				output[tfschemaTag] = iv
-
-			case reflect.Float32, reflect.Float64:
-				fv := fieldVal.Float()
-				debugLogger.Infof("Setting %q to %f", tfschemaTag, fv)
-				output[tfschemaTag] = fv
-
-			case reflect.String:
-				sv := fieldVal.String()
-				debugLogger.Infof("Setting %q to %q", tfschemaTag, sv)
-				output[tfschemaTag] = sv
-
-			case reflect.Bool:
-				bv := fieldVal.Bool()
-				debugLogger.Infof("Setting %q to %t", tfschemaTag, bv)
-				output[tfschemaTag] = bv
-
-			case reflect.Map:
-				iter := fieldVal.MapRange()
-				attr := make(map[string]interface{})
-				for iter.Next() {
-					attr[iter.Key().String()] = iter.Value().Interface()
-				}
-				output[tfschemaTag] = attr
-
-			case reflect.Slice:
-				sv := fieldVal.Slice(0, fieldVal.Len())
-				attr := make([]interface{}, sv.Len())
-				switch sv.Type() {
-				case reflect.TypeOf([]string{}):
-					debugLogger.Infof("Setting %q to []string", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]string, 0)
-					}
-
-				case reflect.TypeOf([]int{}):
-					debugLogger.Infof("Setting %q to []int", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]int, 0)
-					}
-
-				case reflect.TypeOf([]float64{}):
-					debugLogger.Infof("Setting %q to []float64", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]float64, 0)
-					}
-
-				case reflect.TypeOf([]bool{}):
-					debugLogger.Infof("Setting %q to []bool", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]bool, 0)
-					}
-
-				default:
-					for i := 0; i < sv.Len(); i++ {
-						debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
-						debugLogger.Infof("[SLICE] Type %+v", sv.Type())
-						nestedType := sv.Index(i).Type()
-						nestedValue := sv.Index(i)
-
-						fieldName := field.Name
-						serialized, err := recurse(nestedType, nestedValue, fieldName, debugLogger)
-						if err != nil {
-							return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), exists)
-						}
-						attr[i] = serialized
-					}
-					debugLogger.Infof("[SLICE] Setting %q to %+v", tfschemaTag, attr)
-					output[tfschemaTag] = attr
+
+		tag, err := parseModelFieldTag(field)
+		if err != nil {
+			return nil, err
+		}
+		if tag == nil {
+			continue
+		}
+
+		if tag.omitEmpty && fieldVal.IsZero() {
+			debugLogger.Infof("Omitting %q since it's empty", tag.name)
+			continue
+		}
+
+		value, err := encodeValue(nestedPath(path, tag.name), fieldVal, debugLogger)
+		if err != nil {
+			return nil, err
+		}
+		output[tag.name] = value
+	}
+
+	return output, nil
+}
+
+// encodeValue encodes the specified value into the format used by the Terraform State
+func encodeValue(path string, fieldVal reflect.Value, debugLogger Logger) (interface{}, error) {
+	switch fieldVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv := fieldVal.Int()
+		debugLogger.Infof("Setting %q to %d", path, iv)
+		return iv, nil
+
+	case reflect.Float32, reflect.Float64:
+		fv := fieldVal.Float()
+		debugLogger.Infof("Setting %q to %f", path, fv)
+		return fv, nil
+
+	case reflect.String:
+		sv := fieldVal.String()
+		debugLogger.Infof("Setting %q to %q", path, sv)
+		return sv, nil
+
+	case reflect.Bool:
+		bv := fieldVal.Bool()
+		debugLogger.Infof("Setting %q to %t", path, bv)
+		return bv, nil
+
+	case reflect.Interface:
+		if fieldVal.IsNil() {
+			return nil, nil
+		}
+		return fieldVal.Interface(), nil
+
+	case reflect.Ptr:
+		if fieldVal.IsNil() {
+			debugLogger.Infof("Setting %q to nil", path)
+			return nil, nil
+		}
+		return encodeValue(path, fieldVal.Elem(), debugLogger)
+
+	case reflect.Struct:
+		// a single nested block (e.g. `MaxItems: 1`) is represented as a list containing a single item
+		serialized, err := encodeStruct(nestedPath(path, 0), fieldVal.Type(), fieldVal, debugLogger)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{serialized}, nil
+
+	case reflect.Map:
+		if fieldVal.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("the model field for %q must be a map with string keys but got %s", path, fieldVal.Type())
+		}
+
+		iter := fieldVal.MapRange()
+		attr := make(map[string]interface{})
+		for iter.Next() {
+			value := iter.Value()
+			if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+				if value.IsNil() {
+					continue
 				}
-			default:
-				return output, fmt.Errorf("unknown type %+v for key %q", field.Type.Kind(), tfschemaTag)
+				value = value.Elem()
 			}
+			attr[iter.Key().String()] = value.Interface()
 		}
+		return attr, nil
+
+	case reflect.Slice:
+		return encodeSlice(path, fieldVal, debugLogger)
 	}
 
-	return output, nil
+	return nil, fmt.Errorf("unknown type %+v for key %q", fieldVal.Kind(), path)
+}
+
+func encodeSlice(path string, fieldVal reflect.Value, debugLogger Logger) (interface{}, error) {
+	sv := fieldVal.Slice(0, fieldVal.Len())
+
+	switch sv.Type() {
+	case reflect.TypeOf([]string{}), reflect.TypeOf([]int{}), reflect.TypeOf([]float64{}), reflect.TypeOf([]bool{}):
+		debugLogger.Infof("Setting %q to %s", path, sv.Type())
+		if sv.Len() > 0 {
+			return sv.Interface(), nil
+		}
+		return reflect.MakeSlice(sv.Type(), 0, 0).Interface(), nil
+	}
+
+	attr := make([]interface{}, 0, sv.Len())
+	for i := 0; i < sv.Len(); i++ {
+		item := sv.Index(i)
+		debugLogger.Infof("[SLICE] Index %d is %+v", i, item.Interface())
+
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+
+		if item.Kind() == reflect.Struct {
+			serialized, err := encodeStruct(nestedPath(path, i), item.Type(), item, debugLogger)
+			if err != nil {
+				return nil, fmt.Errorf("serializing nested object %q: %+v", nestedPath(path, i), err)
+			}
+			attr = append(attr, serialized)
+			continue
+		}
+
+		value, err := encodeValue(nestedPath(path, i), item, debugLogger)
+		if err != nil {
+			return nil, err
+		}
+		attr = append(attr, value)
+	}
+
+	debugLogger.Infof("[SLICE] Setting %q to %+v", path, attr)
+	return attr, nil
 }
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -647,6 +648,158 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_Pointers(t *testing.T) {
+	type Inner struct {
+		Value *int64 `tfschema:"value"`
+	}
+	type Type struct {
+		Name     *string  `tfschema:"name"`
+		Count    *int64   `tfschema:"count"`
+		Unset    *string  `tfschema:"unset"`
+		Single   *Inner   `tfschema:"single"`
+		NoSingle *Inner   `tfschema:"no_single"`
+		List     []*Inner `tfschema:"list"`
+	}
+	name := "hello"
+	count := int64(0)
+	value := int64(42)
+	encodeTestData{
+		Input: &Type{
+			Name:  &name,
+			Count: &count,
+			Single: &Inner{
+				Value: &value,
+			},
+			List: []*Inner{
+				{
+					Value: &value,
+				},
+				nil,
+			},
+		},
+		Expected: map[string]interface{}{
+			"name":  "hello",
+			"count": int64(0),
+			"unset": nil,
+			"single": []interface{}{
+				map[string]interface{}{
+					"value": int64(42),
+				},
+			},
+			"no_single": nil,
+			"list": []interface{}{
+				map[string]interface{}{
+					"value": int64(42),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_SingleNestedStruct(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		Single Inner `tfschema:"single"`
+	}
+	encodeTestData{
+		Input: &Type{
+			Single: Inner{
+				Value: "hello",
+			},
+		},
+		Expected: map[string]interface{}{
+			"single": []interface{}{
+				map[string]interface{}{
+					"value": "hello",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_Maps(t *testing.T) {
+	type Type struct {
+		Settings map[string]interface{} `tfschema:"settings"`
+		Tags     map[string]*string     `tfschema:"tags"`
+	}
+	value := "world"
+	encodeTestData{
+		Input: &Type{
+			Settings: map[string]interface{}{
+				"hello": "world",
+				"count": 3,
+			},
+			Tags: map[string]*string{
+				"hello": &value,
+				"unset": nil,
+			},
+		},
+		Expected: map[string]interface{}{
+			"settings": map[string]interface{}{
+				"hello": "world",
+				"count": 3,
+			},
+			"tags": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_OmitEmpty(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value,omitempty"`
+		Other string `tfschema:"other"`
+	}
+	type Type struct {
+		Name     string  `tfschema:"name,omitempty"`
+		Optional *string `tfschema:"optional,omitempty"`
+		Count    int     `tfschema:"count,omitempty"`
+		Inner    []Inner `tfschema:"inner,omitempty"`
+		Nested   []Inner `tfschema:"nested"`
+	}
+	encodeTestData{
+		Input: &Type{
+			Count: 2,
+			Nested: []Inner{
+				{},
+			},
+		},
+		Expected: map[string]interface{}{
+			"count": int64(2),
+			"nested": []interface{}{
+				map[string]interface{}{
+					"other": "",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_UnsupportedTypeIncludesFieldPath(t *testing.T) {
+	type Inner struct {
+		Value chan string `tfschema:"value"`
+	}
+	type Type struct {
+		Nested []Inner `tfschema:"nested"`
+	}
+	input := &Type{
+		Nested: []Inner{
+			{},
+		},
+	}
+
+	_, err := recurse(reflect.TypeOf(input).Elem(), reflect.ValueOf(input).Elem(), "Type", ConsoleLogger{})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"nested.0.value"`) {
+		t.Fatalf("expected the error to contain the field path `nested.0.value` but got: %+v", err)
+	}
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
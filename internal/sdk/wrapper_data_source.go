@@ -44,7 +44,7 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, dw.logger)
+			metaData := runArgs(d, meta, dw.logger, dw.dataSource.ResourceType(), "read")
 			return dw.dataSource.Read().Func(ctx, metaData)
 		}),
 		Timeouts: &schema.ResourceTimeout{
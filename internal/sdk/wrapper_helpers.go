@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
 // combineSchema combines the arguments (user-configurable) and attributes (read-only) schema fields
@@ -44,8 +45,14 @@ func combineSchema(arguments map[string]*schema.Schema, attributes map[string]*s
 	return &out, nil
 }
 
-func runArgs(d *schema.ResourceData, meta interface{}, logger Logger) ResourceMetaData {
+// runArgs returns the ResourceMetaData for the specified operation (e.g. `create` or `read`) against this resource,
+// where the Logger writes JSON lines when Structured Logging is enabled (see `features.StructuredLoggingEnabled`)
+func runArgs(d *schema.ResourceData, meta interface{}, logger Logger, resourceType, operation string) ResourceMetaData {
 	client := meta.(*clients.Client)
+	if features.StructuredLoggingEnabled() {
+		logger = NewJSONLogger(resourceType, operation, client.CorrelationRequestID, d, logger)
+	}
+
 	metaData := ResourceMetaData{
 		Client:                   client,
 		Logger:                   logger,
@@ -26,6 +26,30 @@ func NewResourceWrapper(resource Resource) ResourceWrapper {
 	}
 }
 
+// ValidateModelObjectAgainstSchema validates that the Model Object for this Resource (if any) matches its Schema,
+// see ValidateModelObjectAgainstSchema - this is run from the Provider's unit tests rather than at start-up
+func (rw *ResourceWrapper) ValidateModelObjectAgainstSchema() error {
+	modelObj := rw.resource.ModelObject()
+	if modelObj == nil {
+		return nil
+	}
+
+	resourceSchema, err := combineSchema(rw.resource.Arguments(), rw.resource.Attributes())
+	if err != nil {
+		return fmt.Errorf("building Schema: %+v", err)
+	}
+
+	var keysNotInModel []string
+	if v, ok := rw.resource.(ResourceWithSchemaKeysNotInModel); ok {
+		keysNotInModel = v.SchemaKeysNotInModel()
+	}
+	if err := ValidateModelObjectAgainstSchema(modelObj, *resourceSchema, keysNotInModel); err != nil {
+		return fmt.Errorf("validating model for %q against the schema: %+v", rw.resource.ResourceType(), err)
+	}
+
+	return nil
+}
+
 // Resource returns the Terraform Plugin SDK type for this Resource implementation
 func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	resourceSchema, err := combineSchema(rw.resource.Arguments(), rw.resource.Attributes())
@@ -48,7 +72,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 
 		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), "create")
 			err := rw.resource.Create().Func(ctx, metaData)
 			if err != nil {
 				return err
@@ -61,11 +85,11 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), "read")
 			return rw.resource.Read().Func(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), "delete")
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
 
@@ -93,7 +117,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			return nil
 		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 			if v, ok := rw.resource.(ResourceWithCustomImporter); ok {
-				metaData := runArgs(d, meta, rw.logger)
+				metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), "import")
 
 				err := v.CustomImporter()(ctx, metaData)
 				if err != nil {
@@ -111,7 +135,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
 		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), "update")
 
 			err := v.Update().Func(ctx, metaData)
 			if err != nil {
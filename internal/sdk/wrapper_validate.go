@@ -3,7 +3,10 @@ package sdk
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ValidateModelObject validates that the object contains the specified `tfschema` tags
@@ -18,8 +21,6 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("need a pointer to the model object")
 	}
 
-	// TODO: could we also validate that each `tfschema` tag exists in the schema?
-
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
 
@@ -27,40 +28,194 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("cannot resolve pointer to interface")
 	}
 
-	return validateModelObjectRecursively("", objType, objVal)
+	return validateModelObjectRecursively("", objType)
 }
 
-func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal reflect.Value) (errOut error) {
-	defer func() {
-		if r := recover(); r != nil {
-			out, ok := r.(error)
-			if !ok {
-				return
-			}
-
-			errOut = out
-		}
-	}()
+func validateModelObjectRecursively(prefix string, objType reflect.Type) error {
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected %q to be a struct but got %s", prefix, objType.Kind())
+	}
 
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
-		fieldVal := objVal.Field(i)
-
-		if field.Type.Kind() == reflect.Slice {
-			sv := fieldVal.Slice(0, fieldVal.Len())
-			innerType := sv.Type().Elem()
-			innerVal := reflect.Indirect(reflect.New(innerType))
-			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
-			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
+		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+
+		// nested blocks can be defined as a struct, a pointer to a struct, or a slice of either
+		innerType := field.Type
+		if innerType.Kind() == reflect.Slice {
+			innerType = innerType.Elem()
+		}
+		if innerType.Kind() == reflect.Ptr {
+			innerType = innerType.Elem()
+		}
+		if innerType.Kind() == reflect.Struct {
+			if err := validateModelObjectRecursively(fieldName, innerType); err != nil {
 				return err
 			}
 		}
 
-		if _, exists := field.Tag.Lookup("tfschema"); !exists {
-			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+		tag, err := parseModelFieldTag(field)
+		if err != nil {
+			return fmt.Errorf("field %q: %+v", fieldName, err)
+		}
+		if tag == nil {
 			return fmt.Errorf("field %q is missing an `tfschema` label", fieldName)
 		}
 	}
 
 	return nil
 }
+
+// ValidateModelObjectAgainstSchema validates that the `tfschema` struct tags within the model object match
+// the schema for this resource - that is, that each `tfschema` tag has a corresponding key in the schema, that
+// each key in the schema has a corresponding field in the model object and that the Go type of each field
+// is compatible with the schema `Type`. Schema keys specified in keysNotInModel are intentionally not present
+// in the model object (e.g. `identity` or `site_config.0.linux_fx_version`) and so aren't checked
+func ValidateModelObjectAgainstSchema(input interface{}, resourceSchema map[string]*schema.Schema, keysNotInModel []string) error {
+	if input == nil {
+		// model not used for this resource
+		return nil
+	}
+
+	if reflect.TypeOf(input).Kind() != reflect.Ptr {
+		return fmt.Errorf("need a pointer to the model object")
+	}
+
+	objType := reflect.TypeOf(input).Elem()
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("need a pointer to a struct but got a pointer to %s", objType.Kind())
+	}
+
+	ignored := make(map[string]struct{}, len(keysNotInModel))
+	for _, key := range keysNotInModel {
+		ignored[key] = struct{}{}
+	}
+
+	problems := validateStructAgainstSchema("", objType, resourceSchema, ignored)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return fmt.Errorf("the model object doesn't match the schema:\n\n- %s", strings.Join(problems, "\n- "))
+}
+
+func validateStructAgainstSchema(prefix string, objType reflect.Type, resourceSchema map[string]*schema.Schema, ignored map[string]struct{}) []string {
+	problems := make([]string, 0)
+	fields := make(map[string]struct{})
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, err := parseModelFieldTag(field)
+		if err != nil || tag == nil {
+			// these are caught by ValidateModelObject
+			continue
+		}
+
+		path := nestedPath(prefix, tag.name)
+		fields[tag.name] = struct{}{}
+
+		fieldSchema, ok := resourceSchema[tag.name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("the field %q has the `tfschema` tag %q which doesn't exist in the schema", field.Name, path))
+			continue
+		}
+
+		problems = append(problems, validateFieldAgainstSchema(path, field.Name, field.Type, fieldSchema, ignored)...)
+	}
+
+	for key := range resourceSchema {
+		path := nestedPath(prefix, key)
+		if _, ok := ignored[path]; ok {
+			continue
+		}
+
+		if _, ok := fields[key]; !ok {
+			problems = append(problems, fmt.Sprintf("the schema key %q has no corresponding field with a `tfschema` tag in the model object", path))
+		}
+	}
+
+	return problems
+}
+
+func validateFieldAgainstSchema(path, fieldName string, fieldType reflect.Type, fieldSchema *schema.Schema, ignored map[string]struct{}) []string {
+	mismatch := func() []string {
+		return []string{fmt.Sprintf("the field %q for %q has the type %s which isn't compatible with the schema type %s", fieldName, path, fieldType, fieldSchema.Type)}
+	}
+
+	switch fieldSchema.Type {
+	case schema.TypeString, schema.TypeInt, schema.TypeFloat, schema.TypeBool:
+		if !primitiveTypeMatchesSchema(derefType(fieldType), fieldSchema.Type) {
+			return mismatch()
+		}
+		return nil
+
+	case schema.TypeMap:
+		if fieldType.Kind() != reflect.Map || fieldType.Key().Kind() != reflect.String {
+			return mismatch()
+		}
+
+		valueType := derefType(fieldType.Elem())
+		if valueType.Kind() == reflect.Interface {
+			return nil
+		}
+
+		elemType := schema.TypeString
+		if v, ok := fieldSchema.Elem.(*schema.Schema); ok {
+			elemType = v.Type
+		}
+		if !primitiveTypeMatchesSchema(valueType, elemType) {
+			return mismatch()
+		}
+		return nil
+
+	case schema.TypeList, schema.TypeSet:
+		itemType := fieldType
+		if fieldType.Kind() == reflect.Slice {
+			itemType = fieldType.Elem()
+		} else if derefType(fieldType).Kind() != reflect.Struct || fieldSchema.MaxItems != 1 {
+			// a struct (or pointer to a struct) can only be used for a single nested block
+			return mismatch()
+		}
+
+		switch elem := fieldSchema.Elem.(type) {
+		case *schema.Resource:
+			if derefType(itemType).Kind() != reflect.Struct {
+				return mismatch()
+			}
+			return validateStructAgainstSchema(nestedPath(path, 0), derefType(itemType), elem.Schema, ignored)
+
+		case *schema.Schema:
+			if itemType.Kind() == reflect.Interface {
+				return nil
+			}
+			return validateFieldAgainstSchema(nestedPath(path, 0), fieldName, itemType, elem, ignored)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func primitiveTypeMatchesSchema(fieldType reflect.Type, schemaType schema.ValueType) bool {
+	switch fieldType.Kind() {
+	case reflect.String:
+		return schemaType == schema.TypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return schemaType == schema.TypeInt
+	case reflect.Float32, reflect.Float64:
+		return schemaType == schema.TypeFloat
+	case reflect.Bool:
+		return schemaType == schema.TypeBool
+	}
+
+	return false
+}
+
+func derefType(input reflect.Type) reflect.Type {
+	if input.Kind() == reflect.Ptr {
+		return input.Elem()
+	}
+	return input
+}
@@ -1,6 +1,11 @@
 package sdk
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestValidateTopLevelObjectValid(t *testing.T) {
 	type Person struct {
@@ -66,3 +71,160 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateNestedPointerObjectInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int
+	}
+	type Person struct {
+		Name string `tfschema:"name"`
+		Pet  *Pet   `tfschema:"pet"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateTagOptions(t *testing.T) {
+	type Valid struct {
+		Name *string `tfschema:"name,omitempty"`
+	}
+	if err := ValidateModelObject(&Valid{}); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+
+	type Invalid struct {
+		Name string `tfschema:"name,required"`
+	}
+	if err := ValidateModelObject(&Invalid{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateModelObjectAgainstSchemaValid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+	}
+	type Person struct {
+		Name     string            `tfschema:"name"`
+		Age      *int64            `tfschema:"age"`
+		Enabled  bool              `tfschema:"enabled"`
+		Tags     map[string]string `tfschema:"tags"`
+		Aliases  []string          `tfschema:"aliases"`
+		Pets     []Pet             `tfschema:"pets"`
+		Favorite *Pet              `tfschema:"favorite"`
+	}
+	petSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"age": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"aliases": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"pets": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     petSchema,
+		},
+		"favorite": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     petSchema,
+		},
+		"identity": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     petSchema,
+		},
+	}
+	if err := ValidateModelObjectAgainstSchema(&Person{}, resourceSchema, []string{"identity"}); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+}
+
+func TestValidateModelObjectAgainstSchemaInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"nickname"`
+	}
+	type Person struct {
+		Name   string `tfschema:"name"`
+		Age    string `tfschema:"age"`
+		Pets   []Pet  `tfschema:"pets"`
+		Single Pet    `tfschema:"single"`
+	}
+	petSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+	resourceSchema := map[string]*schema.Schema{
+		"age": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"pets": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     petSchema,
+		},
+		"single": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     petSchema,
+		},
+		"location": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	err := ValidateModelObjectAgainstSchema(&Person{}, resourceSchema, nil)
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	for _, expected := range []string{
+		`"name" which doesn't exist in the schema`,
+		`the field "Age" for "age" has the type string`,
+		`"pets.0.nickname" which doesn't exist in the schema`,
+		`the schema key "pets.0.name" has no corresponding field`,
+		`the field "Single" for "single"`,
+		`the schema key "location" has no corresponding field`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q but got %q", expected, err.Error())
+		}
+	}
+}
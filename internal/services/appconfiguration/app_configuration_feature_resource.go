@@ -33,6 +33,7 @@ type FeatureResourceModel struct {
 	ConfigurationStoreId string                       `tfschema:"configuration_store_id"`
 	Description          string                       `tfschema:"description"`
 	Enabled              bool                         `tfschema:"enabled"`
+	Etag                 string                       `tfschema:"etag"`
 	Name                 string                       `tfschema:"name"`
 	Label                string                       `tfschema:"label"`
 	Locked               bool                         `tfschema:"locked"`
@@ -255,6 +256,7 @@ func (k FeatureResource) Read() sdk.ResourceFunc {
 				ConfigurationStoreId: resourceID.ConfigurationStoreId,
 				Description:          fv.Description,
 				Enabled:              fv.Enabled,
+				Etag:                 utils.NormalizeNilableString(kv.Etag),
 				Name:                 fv.ID,
 				Label:                utils.NormalizeNilableString(kv.Label),
 				Tags:                 tags.Flatten(kv.Tags),
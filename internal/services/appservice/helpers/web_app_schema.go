@@ -47,6 +47,7 @@ type SiteConfigWindows struct {
 	ScmMinTlsVersion         string                    `tfschema:"scm_minimum_tls_version"`
 	Cors                     []CorsSetting             `tfschema:"cors"`
 	DetailedErrorLogging     bool                      `tfschema:"detailed_error_logging_enabled"`
+	LinuxFxVersion           string                    `tfschema:"linux_fx_version"`
 	WindowsFxVersion         string                    `tfschema:"windows_fx_version"`
 	VnetRouteAllEnabled      bool                      `tfschema:"vnet_route_all_enabled"`
 	// TODO new properties / blocks
@@ -3583,6 +3584,7 @@ func FlattenSiteConfigWindows(appSiteConfig *web.SiteConfig, currentStack string
 	winAppStack.JavaContainer = utils.NormalizeNilableString(appSiteConfig.JavaContainer)
 	winAppStack.JavaContainerVersion = utils.NormalizeNilableString(appSiteConfig.JavaContainerVersion)
 
+	siteConfig.LinuxFxVersion = utils.NormalizeNilableString(appSiteConfig.LinuxFxVersion)
 	siteConfig.WindowsFxVersion = utils.NormalizeNilableString(appSiteConfig.WindowsFxVersion)
 	if siteConfig.WindowsFxVersion != "" {
 		// Decode the string to docker values
@@ -68,6 +68,7 @@ type LinuxFunctionAppModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = LinuxFunctionAppResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = LinuxFunctionAppResource{}
 
 var _ sdk.ResourceWithCustomImporter = LinuxFunctionAppResource{}
 
@@ -77,6 +78,11 @@ func (r LinuxFunctionAppResource) ModelObject() interface{} {
 	return &LinuxFunctionAppModel{}
 }
 
+func (r LinuxFunctionAppResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r LinuxFunctionAppResource) ResourceType() string {
 	return "azurerm_linux_function_app"
 }
@@ -58,11 +58,17 @@ type LinuxFunctionAppSlotModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = LinuxFunctionAppSlotResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = LinuxFunctionAppSlotResource{}
 
 func (r LinuxFunctionAppSlotResource) ModelObject() interface{} {
 	return &LinuxFunctionAppSlotModel{}
 }
 
+func (r LinuxFunctionAppSlotResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r LinuxFunctionAppSlotResource) ResourceType() string {
 	return "azurerm_linux_function_app_slot"
 }
@@ -55,6 +55,7 @@ type LinuxWebAppModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = LinuxWebAppResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = LinuxWebAppResource{}
 
 var _ sdk.ResourceWithCustomImporter = LinuxWebAppResource{}
 
@@ -202,6 +203,11 @@ func (r LinuxWebAppResource) ModelObject() interface{} {
 	return &LinuxWebAppModel{}
 }
 
+func (r LinuxWebAppResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r LinuxWebAppResource) ResourceType() string {
 	return "azurerm_linux_web_app"
 }
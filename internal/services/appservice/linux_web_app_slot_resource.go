@@ -52,11 +52,17 @@ type LinuxWebAppSlotModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = LinuxWebAppSlotResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = LinuxWebAppSlotResource{}
 
 func (r LinuxWebAppSlotResource) ModelObject() interface{} {
 	return &LinuxWebAppSlotModel{}
 }
 
+func (r LinuxWebAppSlotResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r LinuxWebAppSlotResource) ResourceType() string {
 	return "azurerm_linux_web_app_slot"
 }
@@ -68,6 +68,7 @@ type WindowsFunctionAppModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = WindowsFunctionAppResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = WindowsFunctionAppResource{}
 
 var _ sdk.ResourceWithCustomImporter = WindowsFunctionAppResource{}
 
@@ -77,6 +78,11 @@ func (r WindowsFunctionAppResource) ModelObject() interface{} {
 	return &WindowsFunctionAppModel{}
 }
 
+func (r WindowsFunctionAppResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r WindowsFunctionAppResource) ResourceType() string {
 	return "azurerm_windows_function_app"
 }
@@ -58,11 +58,17 @@ type WindowsFunctionAppSlotModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = WindowsFunctionAppSlotResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = WindowsFunctionAppSlotResource{}
 
 func (r WindowsFunctionAppSlotResource) ModelObject() interface{} {
 	return &WindowsFunctionAppSlotModel{}
 }
 
+func (r WindowsFunctionAppSlotResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r WindowsFunctionAppSlotResource) ResourceType() string {
 	return "azurerm_windows_function_app_slot"
 }
@@ -54,6 +54,7 @@ type WindowsWebAppModel struct {
 }
 
 var _ sdk.ResourceWithCustomImporter = WindowsWebAppResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = WindowsWebAppResource{}
 
 func (r WindowsWebAppResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
@@ -200,6 +201,11 @@ func (r WindowsWebAppResource) ModelObject() interface{} {
 	return &WindowsWebAppModel{}
 }
 
+func (r WindowsWebAppResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r WindowsWebAppResource) ResourceType() string {
 	return "azurerm_windows_web_app"
 }
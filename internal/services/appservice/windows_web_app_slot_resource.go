@@ -49,11 +49,17 @@ type WindowsWebAppSlotModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = WindowsWebAppSlotResource{}
+var _ sdk.ResourceWithSchemaKeysNotInModel = WindowsWebAppSlotResource{}
 
 func (r WindowsWebAppSlotResource) ModelObject() interface{} {
 	return &WindowsWebAppSlotModel{}
 }
 
+func (r WindowsWebAppSlotResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r WindowsWebAppSlotResource) ResourceType() string {
 	return "azurerm_windows_web_app_slot"
 }
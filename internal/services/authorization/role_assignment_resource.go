@@ -136,8 +136,6 @@ func resourceArmRoleAssignment() *pluginsdk.Resource {
 }
 
 func resourceArmRoleAssignmentCreate(d *pluginsdk.ResourceData, meta interface{}) error {
-	roleAssignmentsClient := meta.(*clients.Client).Authorization.RoleAssignmentsClient
-	roleDefinitionsClient := meta.(*clients.Client).Authorization.RoleDefinitionsClient
 	subscriptionClient := meta.(*clients.Client).Subscription.Client
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
@@ -146,6 +144,13 @@ func resourceArmRoleAssignmentCreate(d *pluginsdk.ResourceData, meta interface{}
 	name := d.Get("name").(string)
 	scope := d.Get("scope").(string)
 
+	scopeClient, err := clientForRoleAssignmentScope(meta, scope)
+	if err != nil {
+		return err
+	}
+	roleAssignmentsClient := scopeClient.Authorization.RoleAssignmentsClient
+	roleDefinitionsClient := scopeClient.Authorization.RoleDefinitionsClient
+
 	var roleDefinitionId string
 	if v, ok := d.GetOk("role_definition_id"); ok {
 		roleDefinitionId = v.(string)
@@ -223,7 +228,7 @@ func resourceArmRoleAssignmentCreate(d *pluginsdk.ResourceData, meta interface{}
 		properties.RoleAssignmentProperties.PrincipalType = authorization.ServicePrincipal
 	}
 
-	if err := pluginsdk.Retry(d.Timeout(pluginsdk.TimeoutCreate), retryRoleAssignmentsClient(d, scope, name, properties, meta, tenantId)); err != nil {
+	if err := pluginsdk.Retry(d.Timeout(pluginsdk.TimeoutCreate), retryRoleAssignmentsClient(d, scope, name, properties, roleAssignmentsClient, meta, tenantId)); err != nil {
 		return err
 	}
 
@@ -240,8 +245,6 @@ func resourceArmRoleAssignmentCreate(d *pluginsdk.ResourceData, meta interface{}
 }
 
 func resourceArmRoleAssignmentRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Authorization.RoleAssignmentsClient
-	roleDefinitionsClient := meta.(*clients.Client).Authorization.RoleDefinitionsClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -249,6 +252,13 @@ func resourceArmRoleAssignmentRead(d *pluginsdk.ResourceData, meta interface{})
 	if err != nil {
 		return err
 	}
+
+	scopeClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	client := scopeClient.Authorization.RoleAssignmentsClient
+	roleDefinitionsClient := scopeClient.Authorization.RoleDefinitionsClient
 	resp, err := client.GetByID(ctx, id.AzureResourceID(), id.TenantId)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -289,7 +299,6 @@ func resourceArmRoleAssignmentRead(d *pluginsdk.ResourceData, meta interface{})
 }
 
 func resourceArmRoleAssignmentDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Authorization.RoleAssignmentsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -298,6 +307,12 @@ func resourceArmRoleAssignmentDelete(d *pluginsdk.ResourceData, meta interface{}
 		return err
 	}
 
+	scopeClient, err := clientForRoleAssignmentScope(meta, id.scope)
+	if err != nil {
+		return err
+	}
+	client := scopeClient.Authorization.RoleAssignmentsClient
+
 	resp, err := client.Delete(ctx, id.scope, id.name, id.tenantId)
 	if err != nil {
 		if !utils.ResponseWasNotFound(resp.Response) {
@@ -308,9 +323,8 @@ func resourceArmRoleAssignmentDelete(d *pluginsdk.ResourceData, meta interface{}
 	return nil
 }
 
-func retryRoleAssignmentsClient(d *pluginsdk.ResourceData, scope string, name string, properties authorization.RoleAssignmentCreateParameters, meta interface{}, tenantId string) func() *pluginsdk.RetryError {
+func retryRoleAssignmentsClient(d *pluginsdk.ResourceData, scope string, name string, properties authorization.RoleAssignmentCreateParameters, roleAssignmentsClient *authorization.RoleAssignmentsClient, meta interface{}, tenantId string) func() *pluginsdk.RetryError {
 	return func() *pluginsdk.RetryError {
-		roleAssignmentsClient := meta.(*clients.Client).Authorization.RoleAssignmentsClient
 		ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 		defer cancel()
 
@@ -392,6 +406,18 @@ func roleAssignmentCreateStateRefreshFunc(ctx context.Context, client *authoriza
 	}
 }
 
+// clientForRoleAssignmentScope returns the Client for the Subscription which the specified scope is within, or
+// the configured Client when the scope isn't within a Subscription (e.g. a Management Group)
+func clientForRoleAssignmentScope(meta interface{}, scope string) (*clients.Client, error) {
+	subscriptionId := ""
+	segments := strings.Split(strings.TrimPrefix(scope, "/"), "/")
+	if len(segments) >= 2 && strings.EqualFold(segments[0], "subscriptions") {
+		subscriptionId = segments[1]
+	}
+
+	return meta.(*clients.Client).ForSubscription(subscriptionId)
+}
+
 func getTenantIdBySubscriptionId(ctx context.Context, client *subscriptions.Client, subscriptionId string) (string, error) {
 	resp, err := client.Get(ctx, subscriptionId)
 	if err != nil {
@@ -69,7 +69,9 @@ func resourceCognitiveAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
@@ -172,7 +174,9 @@ func resourceCognitiveAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
@@ -95,7 +95,9 @@ func resourceCognitiveAccountCreate(d *pluginsdk.ResourceData, meta interface{})
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	publicNetworkAccess := cognitiveservicesaccounts.PublicNetworkAccessEnabled
@@ -180,7 +182,9 @@ func resourceCognitiveAccountUpdate(d *pluginsdk.ResourceData, meta interface{})
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	publicNetworkAccess := cognitiveservicesaccounts.PublicNetworkAccessEnabled
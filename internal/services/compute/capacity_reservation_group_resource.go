@@ -0,0 +1,188 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = CapacityReservationGroupResource{}
+
+type CapacityReservationGroupResource struct{}
+
+type CapacityReservationGroupModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	Location          string            `tfschema:"location"`
+	Zones             zones.Schema      `tfschema:"zones"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+func (r CapacityReservationGroupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.CapacityReservationGroupName,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"zones": commonschema.ZonesMultipleOptionalForceNew(),
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r CapacityReservationGroupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r CapacityReservationGroupResource) ModelObject() interface{} {
+	return &CapacityReservationGroupModel{}
+}
+
+func (r CapacityReservationGroupResource) ResourceType() string {
+	return "azurerm_capacity_reservation_group"
+}
+
+func (r CapacityReservationGroupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.CapacityReservationGroupID
+}
+
+func (r CapacityReservationGroupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationGroupsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model CapacityReservationGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewCapacityReservationGroupID(subscriptionId, model.ResourceGroupName, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := compute.CapacityReservationGroup{
+				Location: utils.String(location.Normalize(model.Location)),
+				Tags:     tags.FromTypedObject(model.Tags),
+			}
+			if len(model.Zones) > 0 {
+				parameters.Zones = &model.Zones
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r CapacityReservationGroupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationGroupsClient
+
+			id, err := parse.CapacityReservationGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := CapacityReservationGroupModel{
+				Name:              id.Name,
+				ResourceGroupName: id.ResourceGroup,
+				Location:          location.NormalizeNilable(resp.Location),
+				Tags:              tags.ToTypedObject(resp.Tags),
+			}
+			if resp.Zones != nil {
+				model.Zones = *resp.Zones
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r CapacityReservationGroupResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationGroupsClient
+
+			id, err := parse.CapacityReservationGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model CapacityReservationGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters := compute.CapacityReservationGroupUpdate{
+					Tags: tags.FromTypedObject(model.Tags),
+				}
+				if _, err := client.Update(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r CapacityReservationGroupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationGroupsClient
+
+			id, err := parse.CapacityReservationGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,281 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = CapacityReservationResource{}
+
+type CapacityReservationResource struct{}
+
+type CapacityReservationModel struct {
+	Name                       string                        `tfschema:"name"`
+	CapacityReservationGroupId string                        `tfschema:"capacity_reservation_group_id"`
+	Sku                        []CapacityReservationSkuModel `tfschema:"sku"`
+	Zone                       string                        `tfschema:"zone"`
+	Tags                       map[string]string             `tfschema:"tags"`
+}
+
+type CapacityReservationSkuModel struct {
+	Name     string `tfschema:"name"`
+	Capacity int    `tfschema:"capacity"`
+}
+
+func (r CapacityReservationResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.CapacityReservationName,
+		},
+
+		"capacity_reservation_group_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.CapacityReservationGroupID,
+		},
+
+		"sku": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"capacity": {
+						Type:         pluginsdk.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+				},
+			},
+		},
+
+		"zone": commonschema.ZoneSingleOptionalForceNew(),
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r CapacityReservationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r CapacityReservationResource) ModelObject() interface{} {
+	return &CapacityReservationModel{}
+}
+
+func (r CapacityReservationResource) ResourceType() string {
+	return "azurerm_capacity_reservation"
+}
+
+func (r CapacityReservationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.CapacityReservationID
+}
+
+func (r CapacityReservationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationsClient
+			groupsClient := metadata.Client.Compute.CapacityReservationGroupsClient
+
+			var model CapacityReservationModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId, err := parse.CapacityReservationGroupID(model.CapacityReservationGroupId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewCapacityReservationID(groupId.SubscriptionId, groupId.ResourceGroup, groupId.Name, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			// the Capacity Reservation must be in the same location (and, for zonal groups, one of the zones) as the group
+			group, err := groupsClient.Get(ctx, groupId.ResourceGroup, groupId.Name, "")
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *groupId, err)
+			}
+			if group.Zones != nil && len(*group.Zones) > 0 {
+				if model.Zone == "" {
+					return fmt.Errorf("`zone` must be specified since %s is zonal (zones %q)", *groupId, *group.Zones)
+				}
+				if !skuZoneAvailable(*group.Zones, model.Zone) {
+					return fmt.Errorf("`zone` must be one of the zones of %s (%q) but got %q", *groupId, *group.Zones, model.Zone)
+				}
+			} else if model.Zone != "" {
+				return fmt.Errorf("`zone` can only be specified when %s is zonal", *groupId)
+			}
+
+			parameters := compute.CapacityReservation{
+				Location: group.Location,
+				Sku:      expandCapacityReservationSku(model.Sku),
+				Tags:     tags.FromTypedObject(model.Tags),
+			}
+			if model.Zone != "" {
+				parameters.Zones = &[]string{model.Zone}
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r CapacityReservationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationsClient
+
+			id, err := parse.CapacityReservationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := CapacityReservationModel{
+				Name:                       id.Name,
+				CapacityReservationGroupId: parse.NewCapacityReservationGroupID(id.SubscriptionId, id.ResourceGroup, id.CapacityReservationGroupName).ID(),
+				Sku:                        flattenCapacityReservationSku(resp.Sku),
+				Tags:                       tags.ToTypedObject(resp.Tags),
+			}
+			if resp.Zones != nil && len(*resp.Zones) > 0 {
+				model.Zone = (*resp.Zones)[0]
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r CapacityReservationResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationsClient
+
+			id, err := parse.CapacityReservationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model CapacityReservationModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			parameters := compute.CapacityReservationUpdate{}
+			if metadata.ResourceData.HasChange("sku") {
+				parameters.Sku = expandCapacityReservationSku(model.Sku)
+			}
+			if metadata.ResourceData.HasChange("tags") {
+				parameters.Tags = tags.FromTypedObject(model.Tags)
+			}
+
+			future, err := client.Update(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r CapacityReservationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationsClient
+
+			id, err := parse.CapacityReservationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandCapacityReservationSku(input []CapacityReservationSkuModel) *compute.Sku {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return &compute.Sku{
+		Name:     utils.String(input[0].Name),
+		Capacity: utils.Int64(int64(input[0].Capacity)),
+	}
+}
+
+func flattenCapacityReservationSku(input *compute.Sku) []CapacityReservationSkuModel {
+	if input == nil {
+		return []CapacityReservationSkuModel{}
+	}
+
+	output := CapacityReservationSkuModel{}
+	if input.Name != nil {
+		output.Name = *input.Name
+	}
+	if input.Capacity != nil {
+		output.Capacity = int(*input.Capacity)
+	}
+
+	return []CapacityReservationSkuModel{output}
+}
@@ -0,0 +1,473 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = GalleryApplicationVersionResource{}
+
+type GalleryApplicationVersionResource struct{}
+
+type GalleryApplicationVersionModel struct {
+	Name                 string                                       `tfschema:"name"`
+	GalleryApplicationId string                                       `tfschema:"gallery_application_id"`
+	Location             string                                       `tfschema:"location"`
+	EnableHealthCheck    bool                                         `tfschema:"enable_health_check"`
+	EndOfLifeDate        string                                       `tfschema:"end_of_life_date"`
+	ExcludeFromLatest    bool                                         `tfschema:"exclude_from_latest"`
+	ManageAction         []GalleryApplicationVersionManageActionModel `tfschema:"manage_action"`
+	Source               []GalleryApplicationVersionSourceModel       `tfschema:"source"`
+	TargetRegion         []GalleryApplicationVersionTargetRegionModel `tfschema:"target_region"`
+	Tags                 map[string]string                            `tfschema:"tags"`
+}
+
+type GalleryApplicationVersionManageActionModel struct {
+	Install string `tfschema:"install"`
+	Remove  string `tfschema:"remove"`
+	Update  string `tfschema:"update"`
+}
+
+type GalleryApplicationVersionSourceModel struct {
+	MediaLink                string `tfschema:"media_link"`
+	DefaultConfigurationLink string `tfschema:"default_configuration_link"`
+}
+
+type GalleryApplicationVersionTargetRegionModel struct {
+	Name                 string `tfschema:"name"`
+	RegionalReplicaCount int    `tfschema:"regional_replica_count"`
+	StorageAccountType   string `tfschema:"storage_account_type"`
+}
+
+func (r GalleryApplicationVersionResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.GalleryApplicationVersionName,
+		},
+
+		"gallery_application_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.GalleryApplicationID,
+		},
+
+		"location": commonschema.Location(),
+
+		"enable_health_check": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"end_of_life_date": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+
+		"exclude_from_latest": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"manage_action": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"install": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringLenBetween(1, 4096),
+					},
+
+					"remove": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringLenBetween(1, 4096),
+					},
+
+					"update": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringLenBetween(1, 4096),
+					},
+				},
+			},
+		},
+
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"media_link": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+
+					"default_configuration_link": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+				},
+			},
+		},
+
+		"target_region": galleryTargetRegionSchema(),
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r GalleryApplicationVersionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r GalleryApplicationVersionResource) ModelObject() interface{} {
+	return &GalleryApplicationVersionModel{}
+}
+
+func (r GalleryApplicationVersionResource) ResourceType() string {
+	return "azurerm_gallery_application_version"
+}
+
+func (r GalleryApplicationVersionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.GalleryApplicationVersionID
+}
+
+func (r GalleryApplicationVersionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.GalleryApplicationVersionsClient
+
+			var model GalleryApplicationVersionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := parse.GalleryApplicationID(model.GalleryApplicationId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewGalleryApplicationVersionID(applicationId.SubscriptionId, applicationId.ResourceGroup, applicationId.GalleryName, applicationId.ApplicationName, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters, err := expandGalleryApplicationVersion(model)
+			if err != nil {
+				return err
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName, *parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r GalleryApplicationVersionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.GalleryApplicationVersionsClient
+
+			id, err := parse.GalleryApplicationVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName, compute.ReplicationStatusTypesReplicationStatus)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := GalleryApplicationVersionModel{
+				Name:                 id.VersionName,
+				GalleryApplicationId: parse.NewGalleryApplicationID(id.SubscriptionId, id.ResourceGroup, id.GalleryName, id.ApplicationName).ID(),
+				Location:             location.NormalizeNilable(resp.Location),
+				Tags:                 tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.GalleryApplicationVersionProperties; props != nil {
+				if profile := props.PublishingProfile; profile != nil {
+					if profile.EnableHealthCheck != nil {
+						model.EnableHealthCheck = *profile.EnableHealthCheck
+					}
+					if profile.EndOfLifeDate != nil {
+						model.EndOfLifeDate = profile.EndOfLifeDate.Format(time.RFC3339)
+					}
+					if profile.ExcludeFromLatest != nil {
+						model.ExcludeFromLatest = *profile.ExcludeFromLatest
+					}
+
+					model.ManageAction = flattenGalleryApplicationVersionManageAction(profile.ManageActions)
+					model.Source = flattenGalleryApplicationVersionSource(profile.Source)
+					model.TargetRegion = flattenGalleryApplicationVersionTargetRegions(profile.TargetRegions)
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r GalleryApplicationVersionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.GalleryApplicationVersionsClient
+
+			id, err := parse.GalleryApplicationVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model GalleryApplicationVersionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// as with Shared Image Versions, changes to the `target_region` block (re-)replicate the Version
+			// so the whole Publishing Profile is sent
+			parameters, err := expandGalleryApplicationVersion(model)
+			if err != nil {
+				return err
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName, *parameters)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GalleryApplicationVersionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.GalleryApplicationVersionsClient
+
+			id, err := parse.GalleryApplicationVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			// whilst the deletion completes quickly the replicas are removed asynchronously, during which time
+			// the parent Gallery Application can't be deleted - so we poll until the Version is gone
+			timeout, _ := ctx.Deadline()
+			log.Printf("[DEBUG] Waiting for %s to be eventually deleted", *id)
+			stateConf := &pluginsdk.StateChangeConf{
+				Pending:                   []string{"Exists"},
+				Target:                    []string{"NotFound"},
+				Refresh:                   galleryApplicationVersionDeleteStateRefreshFunc(ctx, client, *id),
+				MinTimeout:                10 * time.Second,
+				ContinuousTargetOccurence: 10,
+				Timeout:                   time.Until(timeout),
+			}
+
+			if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("waiting for %s to be deleted: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func galleryApplicationVersionDeleteStateRefreshFunc(ctx context.Context, client *compute.GalleryApplicationVersionsClient, id parse.GalleryApplicationVersionId) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, id.ApplicationName, id.VersionName, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(res.Response) {
+				return "NotFound", "NotFound", nil
+			}
+
+			return nil, "", fmt.Errorf("polling to check if %s has been deleted: %+v", id, err)
+		}
+
+		return res, "Exists", nil
+	}
+}
+
+func expandGalleryApplicationVersion(model GalleryApplicationVersionModel) (*compute.GalleryApplicationVersion, error) {
+	profile := &compute.GalleryApplicationVersionPublishingProfile{
+		EnableHealthCheck: utils.Bool(model.EnableHealthCheck),
+		ExcludeFromLatest: utils.Bool(model.ExcludeFromLatest),
+		ManageActions:     expandGalleryApplicationVersionManageAction(model.ManageAction),
+		Source:            expandGalleryApplicationVersionSource(model.Source),
+		TargetRegions:     expandGalleryApplicationVersionTargetRegions(model.TargetRegion),
+	}
+
+	if model.EndOfLifeDate != "" {
+		endOfLifeDate, err := time.Parse(time.RFC3339, model.EndOfLifeDate)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `end_of_life_date`: %+v", err)
+		}
+		profile.EndOfLifeDate = &date.Time{Time: endOfLifeDate}
+	}
+
+	return &compute.GalleryApplicationVersion{
+		Location: utils.String(location.Normalize(model.Location)),
+		GalleryApplicationVersionProperties: &compute.GalleryApplicationVersionProperties{
+			PublishingProfile: profile,
+		},
+		Tags: tags.FromTypedObject(model.Tags),
+	}, nil
+}
+
+func expandGalleryApplicationVersionManageAction(input []GalleryApplicationVersionManageActionModel) *compute.UserArtifactManage {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := &compute.UserArtifactManage{
+		Install: utils.String(input[0].Install),
+		Remove:  utils.String(input[0].Remove),
+	}
+	if input[0].Update != "" {
+		output.Update = utils.String(input[0].Update)
+	}
+	return output
+}
+
+func flattenGalleryApplicationVersionManageAction(input *compute.UserArtifactManage) []GalleryApplicationVersionManageActionModel {
+	if input == nil {
+		return []GalleryApplicationVersionManageActionModel{}
+	}
+
+	output := GalleryApplicationVersionManageActionModel{}
+	if input.Install != nil {
+		output.Install = *input.Install
+	}
+	if input.Remove != nil {
+		output.Remove = *input.Remove
+	}
+	if input.Update != nil {
+		output.Update = *input.Update
+	}
+	return []GalleryApplicationVersionManageActionModel{output}
+}
+
+func expandGalleryApplicationVersionSource(input []GalleryApplicationVersionSourceModel) *compute.UserArtifactSource {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := &compute.UserArtifactSource{
+		MediaLink: utils.String(input[0].MediaLink),
+	}
+	if input[0].DefaultConfigurationLink != "" {
+		output.DefaultConfigurationLink = utils.String(input[0].DefaultConfigurationLink)
+	}
+	return output
+}
+
+func flattenGalleryApplicationVersionSource(input *compute.UserArtifactSource) []GalleryApplicationVersionSourceModel {
+	if input == nil {
+		return []GalleryApplicationVersionSourceModel{}
+	}
+
+	output := GalleryApplicationVersionSourceModel{}
+	if input.MediaLink != nil {
+		output.MediaLink = *input.MediaLink
+	}
+	if input.DefaultConfigurationLink != nil {
+		output.DefaultConfigurationLink = *input.DefaultConfigurationLink
+	}
+	return []GalleryApplicationVersionSourceModel{output}
+}
+
+func expandGalleryApplicationVersionTargetRegions(input []GalleryApplicationVersionTargetRegionModel) *[]compute.TargetRegion {
+	results := make([]compute.TargetRegion, 0)
+	for _, v := range input {
+		results = append(results, compute.TargetRegion{
+			Name:                 utils.String(location.Normalize(v.Name)),
+			RegionalReplicaCount: utils.Int32(int32(v.RegionalReplicaCount)),
+			StorageAccountType:   compute.StorageAccountType(v.StorageAccountType),
+		})
+	}
+	return &results
+}
+
+func flattenGalleryApplicationVersionTargetRegions(input *[]compute.TargetRegion) []GalleryApplicationVersionTargetRegionModel {
+	results := make([]GalleryApplicationVersionTargetRegionModel, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		output := GalleryApplicationVersionTargetRegionModel{
+			StorageAccountType: string(v.StorageAccountType),
+		}
+		if v.Name != nil {
+			output.Name = location.Normalize(*v.Name)
+		}
+		if v.RegionalReplicaCount != nil {
+			output.RegionalReplicaCount = int(*v.RegionalReplicaCount)
+		}
+		results = append(results, output)
+	}
+	return results
+}
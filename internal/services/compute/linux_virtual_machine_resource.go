@@ -40,6 +40,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 			return err
 		}, importVirtualMachine(compute.OperatingSystemTypesLinux, "azurerm_linux_virtual_machine")),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineSkuAvailabilityCustomizeDiff()),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(45 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -121,6 +123,18 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 
 			"boot_diagnostics": bootDiagnosticsSchema(),
 
+			"capacity_reservation_group_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: computeValidate.CapacityReservationGroupID,
+				// the Compute/VM API is broken and returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+				ConflictsWith: []string{
+					"availability_set_id",
+					"proximity_placement_group_id",
+				},
+			},
+
 			"computer_name": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -192,6 +206,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				ValidateFunc: azValidate.ISO8601DurationBetween("PT15M", "PT2H"),
 			},
 
+			"gallery_application": virtualMachineGalleryApplicationSchema(),
+
 			"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),
 
 			"license_type": {
@@ -345,7 +361,9 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 
 	id := parse.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -458,6 +476,10 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 		Tags: tags.Expand(t),
 	}
 
+	if v, ok := d.GetOk("gallery_application"); ok {
+		params.VirtualMachineProperties.ApplicationProfile = expandVirtualMachineGalleryApplications(v.([]interface{}))
+	}
+
 	if encryptionAtHostEnabled, ok := d.GetOk("encryption_at_host_enabled"); ok {
 		params.VirtualMachineProperties.SecurityProfile = &compute.SecurityProfile{
 			EncryptionAtHost: utils.Bool(encryptionAtHostEnabled.(bool)),
@@ -556,6 +578,14 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	if v, ok := d.GetOk("capacity_reservation_group_id"); ok {
+		params.CapacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{
+				ID: utils.String(v.(string)),
+			},
+		}
+	}
+
 	if v, ok := d.GetOk("virtual_machine_scale_set_id"); ok {
 		params.VirtualMachineScaleSet = &compute.SubResource{
 			ID: utils.String(v.(string)),
@@ -679,6 +709,10 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 	}
 	d.Set("extensions_time_budget", extensionsTimeBudget)
 
+	if err := d.Set("gallery_application", flattenVirtualMachineGalleryApplications(props.ApplicationProfile)); err != nil {
+		return fmt.Errorf("setting `gallery_application`: %+v", err)
+	}
+
 	// defaulted since BillingProfile isn't returned if it's unset
 	maxBidPrice := float64(-1.0)
 	if props.BillingProfile != nil && props.BillingProfile.MaxPrice != nil {
@@ -696,6 +730,12 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 	if props.Host != nil && props.Host.ID != nil {
 		dedicatedHostId = *props.Host.ID
 	}
+	capacityReservationGroupId := ""
+	if props.CapacityReservation != nil && props.CapacityReservation.CapacityReservationGroup != nil && props.CapacityReservation.CapacityReservationGroup.ID != nil {
+		capacityReservationGroupId = *props.CapacityReservation.CapacityReservationGroup.ID
+	}
+	d.Set("capacity_reservation_group_id", capacityReservationGroupId)
+
 	d.Set("dedicated_host_id", dedicatedHostId)
 
 	dedicatedHostGroupId := ""
@@ -837,7 +877,9 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -915,6 +957,25 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	if d.HasChange("capacity_reservation_group_id") {
+		shouldUpdate = true
+
+		// Code="PropertyChangeNotAllowed" Message="Updating Capacity Reservation Group of VM 'VMNAME' is not allowed as the VM is currently allocated. Please Deallocate the VM and retry the operation."
+		shouldDeallocate = true
+
+		if v, ok := d.GetOk("capacity_reservation_group_id"); ok {
+			update.CapacityReservation = &compute.CapacityReservationProfile{
+				CapacityReservationGroup: &compute.SubResource{
+					ID: utils.String(v.(string)),
+				},
+			}
+		} else {
+			update.CapacityReservation = &compute.CapacityReservationProfile{
+				CapacityReservationGroup: &compute.SubResource{},
+			}
+		}
+	}
+
 	if d.HasChange("dedicated_host_id") {
 		shouldUpdate = true
 
@@ -950,6 +1011,11 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		update.ExtensionsTimeBudget = utils.String(d.Get("extensions_time_budget").(string))
 	}
 
+	if d.HasChange("gallery_application") {
+		shouldUpdate = true
+		update.ApplicationProfile = expandVirtualMachineGalleryApplications(d.Get("gallery_application").([]interface{}))
+	}
+
 	if d.HasChange("max_bid_price") {
 		shouldUpdate = true
 
@@ -1306,7 +1372,9 @@ func resourceLinuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
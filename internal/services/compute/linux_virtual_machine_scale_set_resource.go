@@ -38,6 +38,8 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 			return err
 		}, importVirtualMachineScaleSet(compute.OperatingSystemTypesLinux, "azurerm_linux_virtual_machine_scale_set")),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineScaleSetSkuAvailabilityCustomizeDiff("sku", true)),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(time.Minute * 60),
 			Read:   pluginsdk.DefaultTimeout(time.Minute * 5),
@@ -284,6 +286,18 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 		virtualMachineProfile.ScheduledEventsProfile = ExpandVirtualMachineScaleSetScheduledEventsProfile(v.([]interface{}))
 	}
 
+	if v, ok := d.GetOk("capacity_reservation_group_id"); ok {
+		virtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{
+				ID: utils.String(v.(string)),
+			},
+		}
+	}
+
+	if v, ok := d.GetOk("gallery_application"); ok {
+		virtualMachineProfile.ApplicationProfile = expandVirtualMachineGalleryApplications(v.([]interface{}))
+	}
+
 	scaleInPolicy := d.Get("scale_in_policy").(string)
 	automaticRepairsPolicyRaw := d.Get("automatic_instance_repair").([]interface{})
 	automaticRepairsPolicy := ExpandVirtualMachineScaleSetAutomaticRepairsPolicy(automaticRepairsPolicyRaw)
@@ -766,6 +780,12 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 			return fmt.Errorf("setting `boot_diagnostics`: %+v", err)
 		}
 
+		capacityReservationGroupId := ""
+		if profile.CapacityReservation != nil && profile.CapacityReservation.CapacityReservationGroup != nil && profile.CapacityReservation.CapacityReservationGroup.ID != nil {
+			capacityReservationGroupId = *profile.CapacityReservation.CapacityReservationGroup.ID
+		}
+		d.Set("capacity_reservation_group_id", capacityReservationGroupId)
+
 		// defaulted since BillingProfile isn't returned if it's unset
 		maxBidPrice := float64(-1.0)
 		if profile.BillingProfile != nil && profile.BillingProfile.MaxPrice != nil {
@@ -865,6 +885,10 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 		}
 		d.Set("extensions_time_budget", extensionsTimeBudget)
 
+		if err := d.Set("gallery_application", flattenVirtualMachineGalleryApplications(profile.ApplicationProfile)); err != nil {
+			return fmt.Errorf("setting `gallery_application`: %+v", err)
+		}
+
 		encryptionAtHostEnabled := false
 		vtpmEnabled := false
 		secureBootEnabled := false
@@ -1030,6 +1054,18 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 
 		"boot_diagnostics": bootDiagnosticsSchema(),
 
+		"capacity_reservation_group_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.CapacityReservationGroupID,
+			// the Compute API is broken and returns the Resource Group name in UPPERCASE
+			DiffSuppressFunc: suppress.CaseDifference,
+			ConflictsWith: []string{
+				"proximity_placement_group_id",
+			},
+		},
+
 		"computer_name_prefix": {
 			Type:     pluginsdk.TypeString,
 			Optional: true,
@@ -1090,6 +1126,8 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 			ValidateFunc: azure.ValidateResourceID,
 		},
 
+		"gallery_application": VirtualMachineScaleSetGalleryApplicationSchema(),
+
 		"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),
 
 		"max_bid_price": {
@@ -37,6 +37,8 @@ func resourceManagedDisk() *pluginsdk.Resource {
 			return err
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(managedDiskSkuAvailabilityCustomizeDiff()),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -683,7 +685,9 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		// check instanceView State
 		vmClient := meta.(*clients.Client).Compute.VMClient
 
-		locks.ByName(name, VirtualMachineResourceName)
+		if err := locks.ByName(ctx, name, VirtualMachineResourceName); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByName(name, VirtualMachineResourceName)
 
 		instanceView, err := vmClient.InstanceView(ctx, virtualMachine.ResourceGroup, virtualMachine.Name)
@@ -40,6 +40,8 @@ func resourceOrchestratedVirtualMachineScaleSet() *pluginsdk.Resource {
 			return err
 		}, importOrchestratedVirtualMachineScaleSet),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineScaleSetSkuAvailabilityCustomizeDiff("sku_name", false)),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
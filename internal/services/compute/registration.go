@@ -1,9 +1,15 @@
 package compute
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
+var (
+	_ sdk.TypedServiceRegistration   = Registration{}
+	_ sdk.UntypedServiceRegistration = Registration{}
+)
+
 type Registration struct{}
 
 // Name is the name of this Service
@@ -72,3 +78,23 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 	return resources
 }
+
+// DataSources returns a list of Data Sources supported by this Service
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{
+		RestorePointDataSource{},
+	}
+}
+
+// Resources returns a list of Resources supported by this Service
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		CapacityReservationGroupResource{},
+		CapacityReservationResource{},
+		GalleryApplicationResource{},
+		GalleryApplicationVersionResource{},
+		RestorePointCollectionResource{},
+		RestorePointResource{},
+		VirtualMachineRunCommandResource{},
+	}
+}
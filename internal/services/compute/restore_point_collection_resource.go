@@ -0,0 +1,206 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = RestorePointCollectionResource{}
+
+type RestorePointCollectionResource struct{}
+
+type RestorePointCollectionModel struct {
+	Name                   string            `tfschema:"name"`
+	ResourceGroup          string            `tfschema:"resource_group_name"`
+	Location               string            `tfschema:"location"`
+	SourceVirtualMachineId string            `tfschema:"source_virtual_machine_id"`
+	Tags                   map[string]string `tfschema:"tags"`
+}
+
+func (r RestorePointCollectionResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RestorePointName,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"source_virtual_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.VirtualMachineID,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r RestorePointCollectionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r RestorePointCollectionResource) ModelObject() interface{} {
+	return &RestorePointCollectionModel{}
+}
+
+func (r RestorePointCollectionResource) ResourceType() string {
+	return "azurerm_restore_point_collection"
+}
+
+func (r RestorePointCollectionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.RestorePointCollectionID
+}
+
+func (r RestorePointCollectionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointCollectionsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model RestorePointCollectionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewRestorePointCollectionID(subscriptionId, model.ResourceGroup, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := compute.RestorePointCollection{
+				Location: utils.String(location.Normalize(model.Location)),
+				RestorePointCollectionProperties: &compute.RestorePointCollectionProperties{
+					Source: &compute.RestorePointCollectionSourceProperties{
+						ID: utils.String(model.SourceVirtualMachineId),
+					},
+				},
+				Tags: tags.FromTypedObject(model.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r RestorePointCollectionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointCollectionsClient
+
+			id, err := parse.RestorePointCollectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := RestorePointCollectionModel{
+				Name:          id.Name,
+				ResourceGroup: id.ResourceGroup,
+				Location:      location.NormalizeNilable(resp.Location),
+				Tags:          tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.RestorePointCollectionProperties; props != nil {
+				if props.Source != nil && props.Source.ID != nil {
+					virtualMachineId, err := parse.VirtualMachineID(*props.Source.ID)
+					if err != nil {
+						return fmt.Errorf("parsing `source.id`: %+v", err)
+					}
+					model.SourceVirtualMachineId = virtualMachineId.ID()
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r RestorePointCollectionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointCollectionsClient
+
+			id, err := parse.RestorePointCollectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model RestorePointCollectionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters := compute.RestorePointCollectionUpdate{
+					Tags: tags.FromTypedObject(model.Tags),
+				}
+				if _, err := client.Update(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r RestorePointCollectionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointCollectionsClient
+
+			id, err := parse.RestorePointCollectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// deleting the collection also deletes any Restore Points within it
+			future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
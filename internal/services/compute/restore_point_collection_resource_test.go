@@ -0,0 +1,138 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type RestorePointCollectionResource struct{}
+
+func TestAccRestorePointCollection_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccRestorePointCollection_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccRestorePointCollection_tags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.tags(data, "Production"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("Production"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.tags(data, "Staging"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("Staging"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r RestorePointCollectionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.RestorePointCollectionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.RestorePointCollectionsClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r RestorePointCollectionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "test" {
+  name                      = "acctestrpc-%d"
+  resource_group_name       = azurerm_resource_group.test.name
+  location                  = azurerm_resource_group.test.location
+  source_virtual_machine_id = azurerm_linux_virtual_machine.test.id
+}
+`, LinuxVirtualMachineResource{}.authSSH(data), data.RandomInteger)
+}
+
+func (r RestorePointCollectionResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "import" {
+  name                      = azurerm_restore_point_collection.test.name
+  resource_group_name       = azurerm_restore_point_collection.test.resource_group_name
+  location                  = azurerm_restore_point_collection.test.location
+  source_virtual_machine_id = azurerm_restore_point_collection.test.source_virtual_machine_id
+}
+`, r.basic(data))
+}
+
+func (r RestorePointCollectionResource) tags(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "test" {
+  name                      = "acctestrpc-%d"
+  resource_group_name       = azurerm_resource_group.test.name
+  location                  = azurerm_resource_group.test.location
+  source_virtual_machine_id = azurerm_linux_virtual_machine.test.id
+
+  tags = {
+    environment = "%s"
+  }
+}
+`, LinuxVirtualMachineResource{}.authSSH(data), data.RandomInteger, environment)
+}
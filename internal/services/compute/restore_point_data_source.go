@@ -0,0 +1,188 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.DataSource = RestorePointDataSource{}
+
+type RestorePointDataSource struct{}
+
+type RestorePointDataSourceModel struct {
+	Name                     string   `tfschema:"name"`
+	RestorePointCollectionId string   `tfschema:"restore_point_collection_id"`
+	ConsistencyMode          string   `tfschema:"consistency_mode"`
+	TimeCreated              string   `tfschema:"time_created"`
+	ExcludedDiskIds          []string `tfschema:"excluded_disk_ids"`
+	OsDiskRestorePointId     string   `tfschema:"os_disk_restore_point_id"`
+	DataDiskRestorePointIds  []string `tfschema:"data_disk_restore_point_ids"`
+}
+
+func (r RestorePointDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"restore_point_collection_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.RestorePointCollectionID,
+		},
+
+		// when omitted the most recently created Restore Point within the collection is returned
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validate.RestorePointName,
+		},
+	}
+}
+
+func (r RestorePointDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"consistency_mode": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"time_created": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"excluded_disk_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"os_disk_restore_point_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"data_disk_restore_point_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r RestorePointDataSource) ModelObject() interface{} {
+	return &RestorePointDataSourceModel{}
+}
+
+func (r RestorePointDataSource) ResourceType() string {
+	return "azurerm_restore_point"
+}
+
+func (r RestorePointDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			collectionsClient := metadata.Client.Compute.RestorePointCollectionsClient
+			client := metadata.Client.Compute.RestorePointsClient
+
+			var state RestorePointDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			collectionId, err := parse.RestorePointCollectionID(state.RestorePointCollectionId)
+			if err != nil {
+				return err
+			}
+
+			name := state.Name
+			if name == "" {
+				collection, err := collectionsClient.Get(ctx, collectionId.ResourceGroup, collectionId.Name, compute.RestorePointCollectionExpandOptionsRestorePoints)
+				if err != nil {
+					if utils.ResponseWasNotFound(collection.Response) {
+						return fmt.Errorf("%s was not found", *collectionId)
+					}
+					return fmt.Errorf("retrieving %s: %+v", *collectionId, err)
+				}
+
+				latest := findLatestRestorePoint(collection.RestorePointCollectionProperties)
+				if latest == nil {
+					return fmt.Errorf("no Restore Points were found within %s", *collectionId)
+				}
+				name = *latest.Name
+			}
+
+			id := parse.NewRestorePointID(collectionId.SubscriptionId, collectionId.ResourceGroup, collectionId.Name, name)
+			resp, err := client.Get(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := RestorePointDataSourceModel{
+				Name:                     id.Name,
+				RestorePointCollectionId: collectionId.ID(),
+				ExcludedDiskIds:          make([]string, 0),
+				DataDiskRestorePointIds:  make([]string, 0),
+			}
+
+			if props := resp.RestorePointProperties; props != nil {
+				model.ExcludedDiskIds = flattenRestorePointExcludedDisks(props.ExcludeDisks)
+				model.ConsistencyMode = string(props.ConsistencyMode)
+				if props.TimeCreated != nil {
+					model.TimeCreated = props.TimeCreated.Format(time.RFC3339)
+				}
+
+				if source := props.SourceMetadata; source != nil {
+					if storage := source.StorageProfile; storage != nil {
+						if osDisk := storage.OsDisk; osDisk != nil && osDisk.DiskRestorePoint != nil && osDisk.DiskRestorePoint.ID != nil {
+							model.OsDiskRestorePointId = *osDisk.DiskRestorePoint.ID
+						}
+
+						if storage.DataDisks != nil {
+							for _, dataDisk := range *storage.DataDisks {
+								if dataDisk.DiskRestorePoint != nil && dataDisk.DiskRestorePoint.ID != nil {
+									model.DataDiskRestorePointIds = append(model.DataDiskRestorePointIds, *dataDisk.DiskRestorePoint.ID)
+								}
+							}
+						}
+					}
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+// findLatestRestorePoint returns the most recently created Restore Point within the collection, if any
+func findLatestRestorePoint(input *compute.RestorePointCollectionProperties) *compute.RestorePoint {
+	if input == nil || input.RestorePoints == nil {
+		return nil
+	}
+
+	var latest *compute.RestorePoint
+	for i, v := range *input.RestorePoints {
+		if v.Name == nil || v.RestorePointProperties == nil || v.RestorePointProperties.TimeCreated == nil {
+			continue
+		}
+
+		if latest == nil || v.RestorePointProperties.TimeCreated.After(latest.RestorePointProperties.TimeCreated.Time) {
+			latest = &(*input.RestorePoints)[i]
+		}
+	}
+	return latest
+}
@@ -0,0 +1,73 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type RestorePointDataSource struct{}
+
+func TestAccDataSourceRestorePoint_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_restore_point", "test")
+	r := RestorePointDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("acctestrp-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("consistency_mode").Exists(),
+				check.That(data.ResourceName).Key("time_created").Exists(),
+				check.That(data.ResourceName).Key("os_disk_restore_point_id").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceRestorePoint_latest(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_restore_point", "test")
+	r := RestorePointDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.latest(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("acctestrp2-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("excluded_disk_ids.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (RestorePointDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_restore_point" "test" {
+  name                        = azurerm_restore_point.test.name
+  restore_point_collection_id = azurerm_restore_point.test.restore_point_collection_id
+}
+`, RestorePointResource{}.basic(data))
+}
+
+func (RestorePointDataSource) latest(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point" "second" {
+  name                        = "acctestrp2-%d"
+  restore_point_collection_id = azurerm_restore_point_collection.test.id
+
+  depends_on = [azurerm_restore_point.test]
+}
+
+data "azurerm_restore_point" "test" {
+  restore_point_collection_id = azurerm_restore_point_collection.test.id
+
+  depends_on = [azurerm_restore_point.second]
+}
+`, RestorePointResource{}.basic(data), data.RandomInteger)
+}
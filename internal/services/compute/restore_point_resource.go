@@ -0,0 +1,215 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.Resource = RestorePointResource{}
+
+type RestorePointResource struct{}
+
+type RestorePointModel struct {
+	Name                     string   `tfschema:"name"`
+	RestorePointCollectionId string   `tfschema:"restore_point_collection_id"`
+	ExcludedDiskIds          []string `tfschema:"excluded_disk_ids"`
+	ConsistencyMode          string   `tfschema:"consistency_mode"`
+	TimeCreated              string   `tfschema:"time_created"`
+}
+
+func (r RestorePointResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RestorePointName,
+		},
+
+		"restore_point_collection_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RestorePointCollectionID,
+		},
+
+		"excluded_disk_ids": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validate.ManagedDiskID,
+			},
+		},
+	}
+}
+
+func (r RestorePointResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"consistency_mode": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"time_created": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r RestorePointResource) ModelObject() interface{} {
+	return &RestorePointModel{}
+}
+
+func (r RestorePointResource) ResourceType() string {
+	return "azurerm_restore_point"
+}
+
+func (r RestorePointResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.RestorePointID
+}
+
+func (r RestorePointResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			var model RestorePointModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			collectionId, err := parse.RestorePointCollectionID(model.RestorePointCollectionId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewRestorePointID(collectionId.SubscriptionId, collectionId.ResourceGroup, collectionId.Name, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := compute.RestorePoint{
+				RestorePointProperties: &compute.RestorePointProperties{
+					ExcludeDisks: expandRestorePointExcludedDisks(model.ExcludedDiskIds),
+				},
+			}
+
+			future, err := client.Create(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r RestorePointResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			id, err := parse.RestorePointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := RestorePointModel{
+				Name:                     id.Name,
+				RestorePointCollectionId: parse.NewRestorePointCollectionID(id.SubscriptionId, id.ResourceGroup, id.RestorePointCollectionName).ID(),
+			}
+
+			if props := resp.RestorePointProperties; props != nil {
+				model.ExcludedDiskIds = flattenRestorePointExcludedDisks(props.ExcludeDisks)
+				model.ConsistencyMode = string(props.ConsistencyMode)
+				if props.TimeCreated != nil {
+					model.TimeCreated = props.TimeCreated.Format(time.RFC3339)
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r RestorePointResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			id, err := parse.RestorePointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandRestorePointExcludedDisks(input []string) *[]compute.APIEntityReference {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := make([]compute.APIEntityReference, 0)
+	for _, v := range input {
+		output = append(output, compute.APIEntityReference{
+			ID: utils.String(v),
+		})
+	}
+	return &output
+}
+
+func flattenRestorePointExcludedDisks(input *[]compute.APIEntityReference) []string {
+	output := make([]string, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		if v.ID == nil {
+			continue
+		}
+		output = append(output, *v.ID)
+	}
+	return output
+}
@@ -65,39 +65,7 @@ func resourceSharedImageVersion() *pluginsdk.Resource {
 
 			"resource_group_name": azure.SchemaResourceGroupName(),
 
-			"target_region": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:             pluginsdk.TypeString,
-							Required:         true,
-							StateFunc:        location.StateFunc,
-							DiffSuppressFunc: location.DiffSuppressFunc,
-						},
-
-						"regional_replica_count": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						// The Service API doesn't support to update `storage_account_type`. So it has to recreate the resource for updating `storage_account_type`.
-						// However, `ForceNew` cannot be used since resource would be recreated while adding or removing `target_region`.
-						// And `CustomizeDiff` also cannot be used since it doesn't support in a `Set`.
-						// So currently terraform would directly return the error message from Service API while updating this property. If this property needs to be updated, please recreate this pluginsdk.
-						"storage_account_type": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(compute.StorageAccountTypeStandardLRS),
-								string(compute.StorageAccountTypeStandardZRS),
-							}, false),
-							Default: string(compute.StorageAccountTypeStandardLRS),
-						},
-					},
-				},
-			},
+			"target_region": galleryTargetRegionSchema(),
 
 			"os_disk_snapshot_id": {
 				Type:         pluginsdk.TypeString,
@@ -300,6 +268,44 @@ func sharedImageVersionDeleteStateRefreshFunc(ctx context.Context, client *compu
 	}
 }
 
+// galleryTargetRegionSchema is shared between the Shared Image Version and the Gallery Application Version
+// resources, which are replicated in the same way
+func galleryTargetRegionSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeSet,
+		Required: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:             pluginsdk.TypeString,
+					Required:         true,
+					StateFunc:        location.StateFunc,
+					DiffSuppressFunc: location.DiffSuppressFunc,
+				},
+
+				"regional_replica_count": {
+					Type:     pluginsdk.TypeInt,
+					Required: true,
+				},
+
+				// The Service API doesn't support to update `storage_account_type`. So it has to recreate the resource for updating `storage_account_type`.
+				// However, `ForceNew` cannot be used since resource would be recreated while adding or removing `target_region`.
+				// And `CustomizeDiff` also cannot be used since it doesn't support in a `Set`.
+				// So currently terraform would directly return the error message from Service API while updating this property. If this property needs to be updated, please recreate this pluginsdk.
+				"storage_account_type": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.StorageAccountTypeStandardLRS),
+						string(compute.StorageAccountTypeStandardZRS),
+					}, false),
+					Default: string(compute.StorageAccountTypeStandardLRS),
+				},
+			},
+		},
+	}
+}
+
 func expandSharedImageVersionTargetRegions(d *pluginsdk.ResourceData) *[]compute.TargetRegion {
 	vs := d.Get("target_region").(*pluginsdk.Set)
 	results := make([]compute.TargetRegion, 0)
@@ -0,0 +1,560 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	skuResourceTypeDisks           = "disks"
+	skuResourceTypeVirtualMachines = "virtualmachines"
+
+	// defaultSkuAvailabilityCacheTTL is the default duration for which the Compute SKU's retrieved from
+	// Azure are cached on disk, which can be overridden using `ARM_PROVIDER_SKU_CACHE_TTL`
+	defaultSkuAvailabilityCacheTTL = 24 * time.Hour
+)
+
+// skuAvailabilityCatalogue contains the availability of the Compute SKU's (for Virtual Machines and Managed
+// Disks) available to a Subscription. This is a separate model to the SDK since the SDK doesn't marshal the
+// (read-only) fields we need when caching this on disk.
+type skuAvailabilityCatalogue struct {
+	// Fetched is the time at which this catalogue was retrieved from Azure
+	Fetched time.Time `json:"fetched"`
+
+	// ResourceTypes is a map of the (lower-cased) Resource Type (e.g. `virtualmachines`) to
+	// the (lower-cased) SKU Name (e.g. `standard_f2`) to the availability of that SKU
+	ResourceTypes map[string]map[string]skuAvailability `json:"resource_types"`
+
+	// cached specifies whether this catalogue was read from the on-disk cache (rather than retrieved from
+	// Azure during this run), in which case it may be out of date
+	cached bool
+}
+
+type skuAvailability struct {
+	Name string `json:"name"`
+
+	// PremiumIO specifies whether this SKU supports Premium Storage, only applicable to Virtual Machines
+	PremiumIO bool `json:"premium_io,omitempty"`
+
+	// Locations is a map of the (normalized) Location to the availability of this SKU within it
+	Locations map[string]skuLocationAvailability `json:"locations"`
+}
+
+type skuLocationAvailability struct {
+	// Restricted specifies whether this SKU is unavailable to this Subscription in this Location
+	Restricted bool `json:"restricted,omitempty"`
+
+	// Zones are the Availability Zones this SKU is available in (to this Subscription) within this Location
+	Zones []string `json:"zones,omitempty"`
+
+	// UltraSSDZones are the Availability Zones in which Ultra SSD's can be attached to this SKU
+	UltraSSDZones []string `json:"ultra_ssd_zones,omitempty"`
+}
+
+func (c skuAvailabilityCatalogue) sku(resourceType, name string) *skuAvailability {
+	skus, ok := c.ResourceTypes[resourceType]
+	if !ok {
+		return nil
+	}
+
+	sku, ok := skus[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+
+	return &sku
+}
+
+// validateVirtualMachineSize validates that the specified Virtual Machine Size is available to this Subscription
+// within the specified Location and Zones - and that it supports Ultra SSD's in these Zones when required
+func (c skuAvailabilityCatalogue) validateVirtualMachineSize(field, size, loc string, zones []string, ultraSSDEnabled bool) error {
+	sku := c.sku(skuResourceTypeVirtualMachines, size)
+	if sku == nil {
+		return fmt.Errorf("the `%s` %q was not found in the list of Virtual Machine Sizes available to this Subscription", field, size)
+	}
+
+	normalizedLocation := location.Normalize(loc)
+	availability, ok := sku.Locations[normalizedLocation]
+	if !ok {
+		return fmt.Errorf("the `%s` %q isn't available in the location %q", field, size, normalizedLocation)
+	}
+	if availability.Restricted {
+		return fmt.Errorf("the `%s` %q is restricted for this Subscription in the location %q", field, size, normalizedLocation)
+	}
+
+	for _, zone := range zones {
+		if !skuZoneAvailable(availability.Zones, zone) {
+			return fmt.Errorf("the `%s` %q isn't available in zone %q of the location %q for this Subscription (available zones: %q)", field, size, zone, normalizedLocation, strings.Join(availability.Zones, ","))
+		}
+
+		// whether Ultra SSD's can be used with a regional Virtual Machine differs by Location, so is only checked for zonal Virtual Machines
+		if ultraSSDEnabled && !skuZoneAvailable(availability.UltraSSDZones, zone) {
+			return fmt.Errorf("`ultra_ssd_enabled` requires a `%s` which supports Ultra SSD's in zone %q of the location %q, which %q doesn't", field, zone, normalizedLocation, size)
+		}
+	}
+
+	return nil
+}
+
+// validateDiskStorageAccountType validates that the specified Disk Storage Account Type is available within the
+// specified Location and Zones - and when a Virtual Machine Size is specified, that this supports Premium Storage
+// if required
+func (c skuAvailabilityCatalogue) validateDiskStorageAccountType(field, storageAccountType, loc string, zones []string, size string) error {
+	normalizedLocation := location.Normalize(loc)
+
+	// not every Storage Account Type is listed for every Azure Environment, as such we only validate those we know about
+	if sku := c.sku(skuResourceTypeDisks, storageAccountType); sku != nil {
+		availability, ok := sku.Locations[normalizedLocation]
+		if !ok {
+			return fmt.Errorf("the `%s` %q isn't available in the location %q", field, storageAccountType, normalizedLocation)
+		}
+		if availability.Restricted {
+			return fmt.Errorf("the `%s` %q is restricted for this Subscription in the location %q", field, storageAccountType, normalizedLocation)
+		}
+
+		// Storage Account Types which aren't zonal are available in every zone, but don't list them
+		if len(availability.Zones) > 0 {
+			for _, zone := range zones {
+				if !skuZoneAvailable(availability.Zones, zone) {
+					return fmt.Errorf("the `%s` %q isn't available in zone %q of the location %q for this Subscription (available zones: %q)", field, storageAccountType, zone, normalizedLocation, strings.Join(availability.Zones, ","))
+				}
+			}
+		}
+	}
+
+	if size != "" && strings.HasPrefix(storageAccountType, "Premium_") {
+		if sku := c.sku(skuResourceTypeVirtualMachines, size); sku != nil && !sku.PremiumIO {
+			return fmt.Errorf("the `%s` %q requires a Virtual Machine Size which supports Premium Storage, which %q doesn't", field, storageAccountType, size)
+		}
+	}
+
+	return nil
+}
+
+func skuZoneAvailable(zones []string, zone string) bool {
+	for _, v := range zones {
+		if v == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// add includes the specified Resource SKU in this catalogue, merging the availability for SKU's which are listed
+// more than once (for example the Managed Disk SKU's are listed once per Location and Disk Size)
+func (c *skuAvailabilityCatalogue) add(input compute.ResourceSku) {
+	if input.ResourceType == nil || input.Name == nil {
+		return
+	}
+	resourceType := strings.ToLower(*input.ResourceType)
+	if resourceType != skuResourceTypeDisks && resourceType != skuResourceTypeVirtualMachines {
+		return
+	}
+
+	if c.ResourceTypes == nil {
+		c.ResourceTypes = make(map[string]map[string]skuAvailability)
+	}
+	if _, ok := c.ResourceTypes[resourceType]; !ok {
+		c.ResourceTypes[resourceType] = make(map[string]skuAvailability)
+	}
+
+	key := strings.ToLower(*input.Name)
+	sku, ok := c.ResourceTypes[resourceType][key]
+	if !ok {
+		sku = skuAvailability{
+			Name:      *input.Name,
+			Locations: make(map[string]skuLocationAvailability),
+		}
+	}
+
+	if input.Capabilities != nil {
+		for _, capability := range *input.Capabilities {
+			if capability.Name != nil && *capability.Name == "PremiumIO" && capability.Value != nil && strings.EqualFold(*capability.Value, "True") {
+				sku.PremiumIO = true
+			}
+		}
+	}
+
+	restrictedLocations := make(map[string]struct{})
+	restrictedZones := make(map[string]map[string]struct{})
+	if input.Restrictions != nil {
+		for _, restriction := range *input.Restrictions {
+			if restriction.RestrictionInfo == nil {
+				continue
+			}
+
+			locations := make([]string, 0)
+			if restriction.RestrictionInfo.Locations != nil {
+				locations = *restriction.RestrictionInfo.Locations
+			}
+
+			switch restriction.Type {
+			case compute.ResourceSkuRestrictionsTypeLocation:
+				for _, v := range locations {
+					restrictedLocations[location.Normalize(v)] = struct{}{}
+				}
+
+			case compute.ResourceSkuRestrictionsTypeZone:
+				if restriction.RestrictionInfo.Zones == nil {
+					continue
+				}
+				for _, v := range locations {
+					normalized := location.Normalize(v)
+					if _, ok := restrictedZones[normalized]; !ok {
+						restrictedZones[normalized] = make(map[string]struct{})
+					}
+					for _, zone := range *restriction.RestrictionInfo.Zones {
+						restrictedZones[normalized][zone] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	if input.LocationInfo != nil {
+		for _, info := range *input.LocationInfo {
+			if info.Location == nil {
+				continue
+			}
+			normalizedLocation := location.Normalize(*info.Location)
+
+			availability := skuLocationAvailability{}
+			if _, restricted := restrictedLocations[normalizedLocation]; restricted {
+				availability.Restricted = true
+			}
+
+			if info.Zones != nil {
+				for _, zone := range *info.Zones {
+					if _, restricted := restrictedZones[normalizedLocation][zone]; !restricted {
+						availability.Zones = append(availability.Zones, zone)
+					}
+				}
+			}
+
+			if info.ZoneDetails != nil {
+				for _, details := range *info.ZoneDetails {
+					if details.Name == nil || details.Capabilities == nil {
+						continue
+					}
+
+					for _, capability := range *details.Capabilities {
+						if capability.Name != nil && *capability.Name == "UltraSSDAvailable" && capability.Value != nil && strings.EqualFold(*capability.Value, "True") {
+							availability.UltraSSDZones = append(availability.UltraSSDZones, *details.Name...)
+						}
+					}
+				}
+			}
+
+			existing, exists := sku.Locations[normalizedLocation]
+			sku.Locations[normalizedLocation] = mergeSkuLocationAvailability(existing, availability, exists)
+		}
+	}
+
+	c.ResourceTypes[resourceType][key] = sku
+}
+
+func mergeSkuLocationAvailability(existing, other skuLocationAvailability, exists bool) skuLocationAvailability {
+	if !exists || (existing.Restricted && !other.Restricted) {
+		return other
+	}
+	if other.Restricted {
+		return existing
+	}
+
+	merge := func(first, second []string) []string {
+		values := make(map[string]struct{})
+		for _, v := range append(append([]string{}, first...), second...) {
+			values[v] = struct{}{}
+		}
+		var output []string
+		for v := range values {
+			output = append(output, v)
+		}
+		sort.Strings(output)
+		return output
+	}
+
+	return skuLocationAvailability{
+		Zones:         merge(existing.Zones, other.Zones),
+		UltraSSDZones: merge(existing.UltraSSDZones, other.UltraSSDZones),
+	}
+}
+
+var (
+	skuAvailabilityLock  sync.Mutex
+	skuAvailabilityCache = make(map[string]*skuAvailabilityCatalogue)
+)
+
+// skuAvailabilityForSubscription returns the Compute SKU's available to the Subscription in use, which are
+// cached both in-memory and on disk (for the duration specified in `ARM_PROVIDER_SKU_CACHE_TTL`) since this
+// list is large and rarely changes - unless `refresh` is specified and these were read from the on-disk cache,
+// in which case these are retrieved from Azure again
+func skuAvailabilityForSubscription(ctx context.Context, client *clients.Client, refresh bool) (*skuAvailabilityCatalogue, error) {
+	// this is held whilst retrieving the SKU's so that concurrent plans only retrieve these once
+	skuAvailabilityLock.Lock()
+	defer skuAvailabilityLock.Unlock()
+
+	ttl := skuAvailabilityCacheTTL()
+	key := fmt.Sprintf("%s-%s", strings.ToLower(client.Account.Environment.Name), client.Account.SubscriptionId)
+	if existing, ok := skuAvailabilityCache[key]; ok && time.Since(existing.Fetched) < ttl && !(refresh && existing.cached) {
+		return existing, nil
+	}
+
+	var fileName string
+	if directory := skuAvailabilityCacheDirectory(); directory != "" {
+		fileName = filepath.Join(directory, fmt.Sprintf("compute-skus-%s.json", key))
+		if !refresh {
+			if existing, err := readSkuAvailabilityCatalogue(fileName); err == nil && time.Since(existing.Fetched) < ttl {
+				skuAvailabilityCache[key] = existing
+				return existing, nil
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Retrieving the Compute SKU's available to Subscription %q..", client.Account.SubscriptionId)
+	iterator, err := client.Compute.ResourceSkusClient.ListComplete(ctx, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("listing Compute SKU's: %+v", err)
+	}
+	catalogue := skuAvailabilityCatalogue{
+		Fetched:       time.Now().UTC(),
+		ResourceTypes: make(map[string]map[string]skuAvailability),
+	}
+	for iterator.NotDone() {
+		catalogue.add(iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Compute SKU's: %+v", err)
+		}
+	}
+
+	if fileName != "" {
+		if err := writeSkuAvailabilityCatalogue(fileName, catalogue); err != nil {
+			log.Printf("[WARN] caching the Compute SKU's to %q: %+v", fileName, err)
+		}
+	}
+
+	skuAvailabilityCache[key] = &catalogue
+	return &catalogue, nil
+}
+
+func readSkuAvailabilityCatalogue(fileName string) (*skuAvailabilityCatalogue, error) {
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", fileName, err)
+	}
+
+	var catalogue skuAvailabilityCatalogue
+	if err := json.Unmarshal(contents, &catalogue); err != nil {
+		return nil, fmt.Errorf("unmarshalling %q: %+v", fileName, err)
+	}
+	catalogue.cached = true
+
+	return &catalogue, nil
+}
+
+func writeSkuAvailabilityCatalogue(fileName string, catalogue skuAvailabilityCatalogue) error {
+	contents, err := json.Marshal(catalogue)
+	if err != nil {
+		return fmt.Errorf("marshalling: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		return fmt.Errorf("creating the directory %q: %+v", filepath.Dir(fileName), err)
+	}
+
+	// write to a temporary file first so that concurrent runs of the Provider never read a partially written file
+	tempFile := fmt.Sprintf("%s.%d.tmp", fileName, os.Getpid())
+	if err := os.WriteFile(tempFile, contents, 0o644); err != nil {
+		return fmt.Errorf("writing %q: %+v", tempFile, err)
+	}
+	if err := os.Rename(tempFile, fileName); err != nil {
+		return fmt.Errorf("renaming %q: %+v", tempFile, err)
+	}
+
+	return nil
+}
+
+// skuAvailabilityCacheDirectory returns the directory the Compute SKU's are cached in, which is either
+// `ARM_PROVIDER_SKU_CACHE_DIR` or the Users Cache Directory - or an empty string if neither are available
+func skuAvailabilityCacheDirectory() string {
+	if v := os.Getenv("ARM_PROVIDER_SKU_CACHE_DIR"); v != "" {
+		return v
+	}
+
+	directory, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(directory, "terraform-provider-azurerm")
+}
+
+func skuAvailabilityCacheTTL() time.Duration {
+	v := os.Getenv("ARM_PROVIDER_SKU_CACHE_TTL")
+	if v == "" {
+		return defaultSkuAvailabilityCacheTTL
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("[WARN] parsing `ARM_PROVIDER_SKU_CACHE_TTL` %q as a duration, defaulting to %s: %+v", v, defaultSkuAvailabilityCacheTTL, err)
+		return defaultSkuAvailabilityCacheTTL
+	}
+	return ttl
+}
+
+// skuAvailabilityCustomizeDiff returns a CustomizeDiffFunc which validates that the SKU's used by this Resource are
+// available to this Subscription (in the specified Location and Zones) when the Resource is being created, or when
+// any of the specified keys are changing - so that this is surfaced during the plan rather than part-way through
+// an apply.
+//
+// This is best-effort: when the values aren't known during the plan, or the SKU's can't be retrieved from Azure
+// (for example due to permissions) this validation is skipped. Since the SKU's read from the on-disk cache may be
+// out of date, these are retrieved from Azure again before failing the validation.
+func skuAvailabilityCustomizeDiff(validate func(d *pluginsdk.ResourceDiff, catalogue skuAvailabilityCatalogue) error, keys ...string) pluginsdk.CustomizeDiffFunc {
+	keys = append([]string{"location"}, keys...)
+
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if !features.SkuAvailabilityValidationEnabled() {
+			return nil
+		}
+
+		changed := d.Id() == ""
+		for _, key := range keys {
+			if d.HasChange(key) {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		for _, key := range keys {
+			if !d.NewValueKnown(key) {
+				return nil
+			}
+		}
+
+		client, ok := meta.(*clients.Client)
+		if !ok || client.Account == nil || client.Compute == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+
+		catalogue, err := skuAvailabilityForSubscription(ctx, client, false)
+		if err != nil {
+			log.Printf("[WARN] skipping the validation of Compute SKU availability: %+v", err)
+			return nil
+		}
+
+		err = validate(d, *catalogue)
+		if err == nil || !catalogue.cached {
+			return err
+		}
+
+		log.Printf("[DEBUG] validating against the cached Compute SKU's failed, retrieving these again: %+v", err)
+		catalogue, err = skuAvailabilityForSubscription(ctx, client, true)
+		if err != nil {
+			log.Printf("[WARN] skipping the validation of Compute SKU availability: %+v", err)
+			return nil
+		}
+
+		return validate(d, *catalogue)
+	}
+}
+
+// virtualMachineSkuAvailabilityCustomizeDiff validates the availability of the `size`, `zone`, Ultra SSD
+// and OS Disk `storage_account_type` for the `azurerm_linux_virtual_machine` and `azurerm_windows_virtual_machine` resources
+func virtualMachineSkuAvailabilityCustomizeDiff() pluginsdk.CustomizeDiffFunc {
+	return skuAvailabilityCustomizeDiff(func(d *pluginsdk.ResourceDiff, catalogue skuAvailabilityCatalogue) error {
+		size := d.Get("size").(string)
+		loc := d.Get("location").(string)
+		zones := make([]string, 0)
+		if v := d.Get("zone").(string); v != "" {
+			zones = append(zones, v)
+		}
+		ultraSSDEnabled := d.Get("additional_capabilities.0.ultra_ssd_enabled").(bool)
+
+		if err := catalogue.validateVirtualMachineSize("size", size, loc, zones, ultraSSDEnabled); err != nil {
+			return err
+		}
+
+		return catalogue.validateDiskStorageAccountType("os_disk.0.storage_account_type", d.Get("os_disk.0.storage_account_type").(string), loc, zones, size)
+	}, "size", "zone", "additional_capabilities", "os_disk")
+}
+
+// virtualMachineScaleSetSkuAvailabilityCustomizeDiff validates the availability of the Virtual Machine Size (specified
+// in the `sizeField`), `zones`, Ultra SSD and OS/Data Disk `storage_account_type` for the Virtual Machine Scale Set resources
+func virtualMachineScaleSetSkuAvailabilityCustomizeDiff(sizeField string, supportsUltraSSD bool) pluginsdk.CustomizeDiffFunc {
+	keys := []string{sizeField, "zones", "os_disk", "data_disk"}
+	if supportsUltraSSD {
+		keys = append(keys, "additional_capabilities")
+	}
+
+	return skuAvailabilityCustomizeDiff(func(d *pluginsdk.ResourceDiff, catalogue skuAvailabilityCatalogue) error {
+		size := d.Get(sizeField).(string)
+		loc := d.Get("location").(string)
+		zones := make([]string, 0)
+		for _, v := range d.Get("zones").(*pluginsdk.Set).List() {
+			zones = append(zones, v.(string))
+		}
+		ultraSSDEnabled := false
+		if supportsUltraSSD {
+			ultraSSDEnabled = d.Get("additional_capabilities.0.ultra_ssd_enabled").(bool)
+		}
+
+		// the `sku_name` is optional for Orchestrated Virtual Machine Scale Sets
+		if size != "" {
+			if err := catalogue.validateVirtualMachineSize(sizeField, size, loc, zones, ultraSSDEnabled); err != nil {
+				return err
+			}
+		}
+
+		if err := catalogue.validateDiskStorageAccountType("os_disk.0.storage_account_type", d.Get("os_disk.0.storage_account_type").(string), loc, zones, size); err != nil {
+			return err
+		}
+
+		for i, raw := range d.Get("data_disk").([]interface{}) {
+			v, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			field := fmt.Sprintf("data_disk.%d.storage_account_type", i)
+			if err := catalogue.validateDiskStorageAccountType(field, v["storage_account_type"].(string), loc, zones, size); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, keys...)
+}
+
+// managedDiskSkuAvailabilityCustomizeDiff validates the availability of the `storage_account_type` and `zone`
+// for the `azurerm_managed_disk` resource
+func managedDiskSkuAvailabilityCustomizeDiff() pluginsdk.CustomizeDiffFunc {
+	return skuAvailabilityCustomizeDiff(func(d *pluginsdk.ResourceDiff, catalogue skuAvailabilityCatalogue) error {
+		zones := make([]string, 0)
+		if v := d.Get("zone").(string); v != "" {
+			zones = append(zones, v)
+		}
+
+		return catalogue.validateDiskStorageAccountType("storage_account_type", d.Get("storage_account_type").(string), d.Get("location").(string), zones, "")
+	}, "storage_account_type", "zone")
+}
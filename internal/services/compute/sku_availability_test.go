@@ -0,0 +1,306 @@
+package compute
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func testSkuAvailabilityCatalogue() skuAvailabilityCatalogue {
+	catalogue := skuAvailabilityCatalogue{}
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("virtualMachines"),
+		Name:         utils.String("Standard_D2s_v3"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: utils.String("PremiumIO"), Value: utils.String("True")},
+		},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: utils.String("West Europe"),
+				Zones:    &[]string{"1", "2", "3"},
+				ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+					{
+						Name: &[]string{"1", "2"},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: utils.String("UltraSSDAvailable"), Value: utils.String("True")},
+						},
+					},
+				},
+			},
+			{
+				Location: utils.String("eastus"),
+				Zones:    &[]string{"1", "2", "3"},
+			},
+		},
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{
+				Type: compute.ResourceSkuRestrictionsTypeZone,
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+					Locations: &[]string{"eastus"},
+					Zones:     &[]string{"3"},
+				},
+			},
+		},
+	})
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("virtualMachines"),
+		Name:         utils.String("Standard_F2"),
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: utils.String("westeurope"),
+			},
+			{
+				Location: utils.String("northeurope"),
+			},
+		},
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{
+				Type: compute.ResourceSkuRestrictionsTypeLocation,
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+					Locations: &[]string{"northeurope"},
+				},
+			},
+		},
+	})
+
+	// Managed Disk SKU's are listed once per Location and Disk Size
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("disks"),
+		Name:         utils.String("UltraSSD_LRS"),
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: utils.String("westeurope"),
+				Zones:    &[]string{"1"},
+			},
+		},
+	})
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("disks"),
+		Name:         utils.String("UltraSSD_LRS"),
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: utils.String("westeurope"),
+				Zones:    &[]string{"2"},
+			},
+		},
+	})
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("disks"),
+		Name:         utils.String("Premium_LRS"),
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: utils.String("westeurope"),
+			},
+		},
+	})
+
+	// other Resource Types aren't included
+	catalogue.add(compute.ResourceSku{
+		ResourceType: utils.String("availabilitySets"),
+		Name:         utils.String("Aligned"),
+	})
+
+	return catalogue
+}
+
+func TestSkuAvailabilityCatalogueAdd(t *testing.T) {
+	catalogue := testSkuAvailabilityCatalogue()
+
+	if _, ok := catalogue.ResourceTypes["availabilitysets"]; ok {
+		t.Fatalf("expected `availabilitysets` not to be included")
+	}
+
+	ultraSSD := catalogue.sku(skuResourceTypeDisks, "ultrassd_lrs")
+	if ultraSSD == nil {
+		t.Fatalf("expected `UltraSSD_LRS` to be included")
+	}
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(ultraSSD.Locations["westeurope"].Zones, expected) {
+		t.Fatalf("expected the zones for `UltraSSD_LRS` to be %+v but got %+v", expected, ultraSSD.Locations["westeurope"].Zones)
+	}
+
+	size := catalogue.sku(skuResourceTypeVirtualMachines, "Standard_D2s_v3")
+	if size == nil {
+		t.Fatalf("expected `Standard_D2s_v3` to be included")
+	}
+	if !size.PremiumIO {
+		t.Fatalf("expected `Standard_D2s_v3` to support Premium Storage")
+	}
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(size.Locations["eastus"].Zones, expected) {
+		t.Fatalf("expected the restricted zone to be removed, expected %+v but got %+v", expected, size.Locations["eastus"].Zones)
+	}
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(size.Locations["westeurope"].UltraSSDZones, expected) {
+		t.Fatalf("expected the Ultra SSD zones to be %+v but got %+v", expected, size.Locations["westeurope"].UltraSSDZones)
+	}
+}
+
+func TestSkuAvailabilityCatalogueValidateVirtualMachineSize(t *testing.T) {
+	catalogue := testSkuAvailabilityCatalogue()
+
+	cases := []struct {
+		Size            string
+		Location        string
+		Zones           []string
+		UltraSSDEnabled bool
+		Error           bool
+	}{
+		{
+			// unknown size
+			Size:     "Standard_Unknown",
+			Location: "westeurope",
+			Error:    true,
+		},
+		{
+			Size:     "standard_d2s_v3",
+			Location: "West Europe",
+		},
+		{
+			// not available in this location
+			Size:     "Standard_D2s_v3",
+			Location: "uksouth",
+			Error:    true,
+		},
+		{
+			// restricted in this location
+			Size:     "Standard_F2",
+			Location: "northeurope",
+			Error:    true,
+		},
+		{
+			Size:     "Standard_D2s_v3",
+			Location: "eastus",
+			Zones:    []string{"2"},
+		},
+		{
+			// restricted zone
+			Size:     "Standard_D2s_v3",
+			Location: "eastus",
+			Zones:    []string{"3"},
+			Error:    true,
+		},
+		{
+			// zones aren't supported
+			Size:     "Standard_F2",
+			Location: "westeurope",
+			Zones:    []string{"1"},
+			Error:    true,
+		},
+		{
+			Size:            "Standard_D2s_v3",
+			Location:        "westeurope",
+			Zones:           []string{"2"},
+			UltraSSDEnabled: true,
+		},
+		{
+			// ultra ssd's aren't available in this zone
+			Size:            "Standard_D2s_v3",
+			Location:        "westeurope",
+			Zones:           []string{"3"},
+			UltraSSDEnabled: true,
+			Error:           true,
+		},
+		{
+			// regional virtual machines aren't validated for ultra ssd's
+			Size:            "Standard_F2",
+			Location:        "westeurope",
+			UltraSSDEnabled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q in %q (zones %+v / ultra ssd %t)", tc.Size, tc.Location, tc.Zones, tc.UltraSSDEnabled)
+
+		err := catalogue.validateVirtualMachineSize("size", tc.Size, tc.Location, tc.Zones, tc.UltraSSDEnabled)
+		if tc.Error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !tc.Error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestSkuAvailabilityCatalogueValidateDiskStorageAccountType(t *testing.T) {
+	catalogue := testSkuAvailabilityCatalogue()
+
+	cases := []struct {
+		StorageAccountType string
+		Location           string
+		Zones              []string
+		Size               string
+		Error              bool
+	}{
+		{
+			// storage account types which aren't listed aren't validated
+			StorageAccountType: "Standard_LRS",
+			Location:           "westeurope",
+			Zones:              []string{"3"},
+		},
+		{
+			StorageAccountType: "UltraSSD_LRS",
+			Location:           "westeurope",
+			Zones:              []string{"2"},
+		},
+		{
+			// not available in this zone
+			StorageAccountType: "UltraSSD_LRS",
+			Location:           "westeurope",
+			Zones:              []string{"3"},
+			Error:              true,
+		},
+		{
+			// not available in this location
+			StorageAccountType: "UltraSSD_LRS",
+			Location:           "eastus",
+			Error:              true,
+		},
+		{
+			// non-zonal storage account types are available in every zone
+			StorageAccountType: "Premium_LRS",
+			Location:           "westeurope",
+			Zones:              []string{"3"},
+			Size:               "Standard_D2s_v3",
+		},
+		{
+			// the size doesn't support premium storage
+			StorageAccountType: "Premium_LRS",
+			Location:           "westeurope",
+			Size:               "Standard_F2",
+			Error:              true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q in %q (zones %+v / size %q)", tc.StorageAccountType, tc.Location, tc.Zones, tc.Size)
+
+		err := catalogue.validateDiskStorageAccountType("storage_account_type", tc.StorageAccountType, tc.Location, tc.Zones, tc.Size)
+		if tc.Error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !tc.Error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestSkuAvailabilityCatalogueCache(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "nested", "compute-skus.json")
+
+	expected := testSkuAvailabilityCatalogue()
+	if err := writeSkuAvailabilityCatalogue(fileName, expected); err != nil {
+		t.Fatalf("writing: %+v", err)
+	}
+
+	actual, err := readSkuAvailabilityCatalogue(fileName)
+	if err != nil {
+		t.Fatalf("reading: %+v", err)
+	}
+	if !reflect.DeepEqual(actual.ResourceTypes, expected.ResourceTypes) {
+		t.Fatalf("expected %+v but got %+v", expected.ResourceTypes, actual.ResourceTypes)
+	}
+	if !actual.cached {
+		t.Fatalf("expected the catalogue read from disk to be marked as cached")
+	}
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func CapacityReservationGroupName(i interface{}, k string) (warnings []string, errors []error) {
+	return capacityReservationName(i, k)
+}
+
+func CapacityReservationName(i interface{}, k string) (warnings []string, errors []error) {
+	return capacityReservationName(i, k)
+}
+
+func capacityReservationName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// the name can contain a-z, A-Z, 0-9, underscore, dot or hyphen - must start with a letter or number
+	// and cannot end with a hyphen or dot
+	if matched := regexp.MustCompile(`^[^_\W]([\w-.]{0,78}\w)?$`).MatchString(v); !matched {
+		errors = append(errors, fmt.Errorf("%s must be between 1 - 80 characters long, start with a letter or number, end with a letter, number or underscore and contain only letters, numbers, underscores, periods or hyphens", k))
+	}
+	return
+}
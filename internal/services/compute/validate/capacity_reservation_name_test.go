@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCapacityReservationName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// single character
+			input:    "a",
+			expected: true,
+		},
+		{
+			// basic example
+			input:    "hello",
+			expected: true,
+		},
+		{
+			// can't start with an underscore
+			input:    "_hello",
+			expected: false,
+		},
+		{
+			// can't start with a hyphen
+			input:    "-hello",
+			expected: false,
+		},
+		{
+			// dot, hyphen and underscore in middle
+			input:    "hello.wor-ld_1",
+			expected: true,
+		},
+		{
+			// can end with an underscore
+			input:    "hello_",
+			expected: true,
+		},
+		{
+			// can't end with a hyphen
+			input:    "hello-",
+			expected: false,
+		},
+		{
+			// can't end with a dot
+			input:    "hello.",
+			expected: false,
+		},
+		{
+			// can't contain an exclamation mark
+			input:    "hello!",
+			expected: false,
+		},
+		{
+			// 80 characters
+			input:    strings.Repeat("a", 80),
+			expected: true,
+		},
+		{
+			// 81 characters
+			input:    strings.Repeat("a", 81),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := CapacityReservationName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
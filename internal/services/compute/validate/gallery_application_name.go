@@ -0,0 +1,34 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func GalleryApplicationName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// the name can contain a-z, A-Z, 0-9, underscore, dot or hyphen - but must start and end with a letter or number
+	if matched := regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,78}[a-zA-Z0-9])?$`).MatchString(v); !matched {
+		errors = append(errors, fmt.Errorf("%s must be between 1 - 80 characters long, start and end with a letter or number and contain only letters, numbers, underscores, periods or hyphens", k))
+	}
+	return
+}
+
+func GalleryApplicationVersionName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// unlike Shared Image Versions, `latest` and `recent` aren't valid names for a Gallery Application Version
+	if matched := regexp.MustCompile(`^[0-9]{1,10}\.[0-9]{1,10}\.[0-9]{1,10}$`).MatchString(v); !matched {
+		errors = append(errors, fmt.Errorf("expected %s to be in the format `1.2.3` but got %q", k, v))
+	}
+	return
+}
@@ -0,0 +1,132 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGalleryApplicationName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// single character
+			input:    "a",
+			expected: true,
+		},
+		{
+			// basic example
+			input:    "hello",
+			expected: true,
+		},
+		{
+			// can't start with an underscore
+			input:    "_hello",
+			expected: false,
+		},
+		{
+			// dot, hyphen and underscore in middle
+			input:    "hello.wor-ld_1",
+			expected: true,
+		},
+		{
+			// can't end with an underscore
+			input:    "hello_",
+			expected: false,
+		},
+		{
+			// can't end with a hyphen
+			input:    "hello-",
+			expected: false,
+		},
+		{
+			// can't contain an exclamation mark
+			input:    "hello!",
+			expected: false,
+		},
+		{
+			// 80 characters
+			input:    strings.Repeat("a", 80),
+			expected: true,
+		},
+		{
+			// 81 characters
+			input:    strings.Repeat("a", 81),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := GalleryApplicationName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestGalleryApplicationVersionName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// basic example
+			input:    "1.0.0",
+			expected: true,
+		},
+		{
+			// major and minor only
+			input:    "1.0",
+			expected: false,
+		},
+		{
+			// four segments
+			input:    "1.0.0.0",
+			expected: false,
+		},
+		{
+			// letters
+			input:    "1.0.a",
+			expected: false,
+		},
+		{
+			// only valid for Shared Image Versions
+			input:    "latest",
+			expected: false,
+		},
+		{
+			// 10 digits per segment
+			input:    "1234567890.0.0",
+			expected: true,
+		},
+		{
+			// 11 digits per segment
+			input:    "12345678901.0.0",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := GalleryApplicationVersionName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RestorePointName validates the name of both a Restore Point Collection and a Restore Point
+func RestorePointName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// the name can contain a-z, A-Z, 0-9, underscore, dot or hyphen - but must start with a letter or number and end with a letter, number or underscore
+	if matched := regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,78}[a-zA-Z0-9_])?$`).MatchString(v); !matched {
+		errors = append(errors, fmt.Errorf("%s must be between 1 - 80 characters long, start with a letter or number, end with a letter, number or underscore and contain only letters, numbers, underscores, periods or hyphens", k))
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRestorePointName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// single character
+			input:    "a",
+			expected: true,
+		},
+		{
+			// basic example
+			input:    "restorePoint1",
+			expected: true,
+		},
+		{
+			// can't start with a hyphen
+			input:    "-hello",
+			expected: false,
+		},
+		{
+			// dot, hyphen and underscore in middle
+			input:    "hello.wor-ld_1",
+			expected: true,
+		},
+		{
+			// can end with an underscore
+			input:    "hello_",
+			expected: true,
+		},
+		{
+			// can't end with a period
+			input:    "hello.",
+			expected: false,
+		},
+		{
+			// can't contain a slash
+			input:    "hello/world",
+			expected: false,
+		},
+		{
+			// 80 characters
+			input:    strings.Repeat("a", 80),
+			expected: true,
+		},
+		{
+			// 81 characters
+			input:    strings.Repeat("a", 81),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := RestorePointName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -67,6 +67,105 @@ func flattenVirtualMachineAdditionalCapabilities(input *compute.AdditionalCapabi
 	}
 }
 
+func virtualMachineGalleryApplicationSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 100,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"version_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validate.GalleryApplicationVersionID,
+				},
+
+				"configuration_blob_uri": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+
+				"order": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntBetween(0, 2147483647),
+				},
+
+				"tag": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineGalleryApplications(input []interface{}) *compute.ApplicationProfile {
+	applications := make([]compute.VMGalleryApplication, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		application := compute.VMGalleryApplication{
+			PackageReferenceID: utils.String(raw["version_id"].(string)),
+			Order:              utils.Int32(int32(raw["order"].(int))),
+		}
+		if configurationReference := raw["configuration_blob_uri"].(string); configurationReference != "" {
+			application.ConfigurationReference = utils.String(configurationReference)
+		}
+		if tag := raw["tag"].(string); tag != "" {
+			application.Tags = utils.String(tag)
+		}
+
+		applications = append(applications, application)
+	}
+
+	return &compute.ApplicationProfile{
+		GalleryApplications: &applications,
+	}
+}
+
+func flattenVirtualMachineGalleryApplications(input *compute.ApplicationProfile) []interface{} {
+	if input == nil || input.GalleryApplications == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, v := range *input.GalleryApplications {
+		versionId := ""
+		if v.PackageReferenceID != nil {
+			versionId = *v.PackageReferenceID
+		}
+
+		configurationReference := ""
+		if v.ConfigurationReference != nil {
+			configurationReference = *v.ConfigurationReference
+		}
+
+		order := 0
+		if v.Order != nil {
+			order = int(*v.Order)
+		}
+
+		tag := ""
+		if v.Tags != nil {
+			tag = *v.Tags
+		}
+
+		output = append(output, map[string]interface{}{
+			"version_id":             versionId,
+			"configuration_blob_uri": configurationReference,
+			"order":                  order,
+			"tag":                    tag,
+		})
+	}
+
+	return output
+}
+
 func expandVirtualMachineIdentity(input []interface{}) (*compute.VirtualMachineIdentity, error) {
 	expanded, err := identity.ExpandSystemAndUserAssignedMap(input)
 	if err != nil {
@@ -99,7 +99,9 @@ func resourceVirtualMachineDataDiskAttachmentCreateUpdate(d *pluginsdk.ResourceD
 		return fmt.Errorf("parsing Virtual Machine ID %q: %+v", parsedVirtualMachineId.ID(), err)
 	}
 
-	locks.ByName(parsedVirtualMachineId.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, parsedVirtualMachineId.Name, VirtualMachineResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedVirtualMachineId.Name, VirtualMachineResourceName)
 
 	virtualMachine, err := client.Get(ctx, parsedVirtualMachineId.ResourceGroup, parsedVirtualMachineId.Name, "")
@@ -253,7 +255,9 @@ func resourceVirtualMachineDataDiskAttachmentDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	virtualMachine, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineName, "")
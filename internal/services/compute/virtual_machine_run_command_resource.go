@@ -0,0 +1,552 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var (
+	_ sdk.ResourceWithUpdate        = VirtualMachineRunCommandResource{}
+	_ sdk.ResourceWithCustomizeDiff = VirtualMachineRunCommandResource{}
+)
+
+type VirtualMachineRunCommandResource struct{}
+
+type VirtualMachineRunCommandModel struct {
+	Name               string                                      `tfschema:"name"`
+	VirtualMachineId   string                                      `tfschema:"virtual_machine_id"`
+	Location           string                                      `tfschema:"location"`
+	Source             []VirtualMachineRunCommandSourceModel       `tfschema:"source"`
+	Parameter          []VirtualMachineRunCommandParameterModel    `tfschema:"parameter"`
+	ProtectedParameter []VirtualMachineRunCommandParameterModel    `tfschema:"protected_parameter"`
+	AsyncExecution     bool                                        `tfschema:"async_execution"`
+	RunAsUser          string                                      `tfschema:"run_as_user"`
+	RunAsPassword      string                                      `tfschema:"run_as_password"`
+	TimeoutInSeconds   int                                         `tfschema:"timeout_in_seconds"`
+	OutputBlobUri      string                                      `tfschema:"output_blob_uri"`
+	ErrorBlobUri       string                                      `tfschema:"error_blob_uri"`
+	Tags               map[string]string                           `tfschema:"tags"`
+	InstanceView       []VirtualMachineRunCommandInstanceViewModel `tfschema:"instance_view"`
+}
+
+type VirtualMachineRunCommandSourceModel struct {
+	Script    string `tfschema:"script"`
+	ScriptUri string `tfschema:"script_uri"`
+	CommandId string `tfschema:"command_id"`
+}
+
+type VirtualMachineRunCommandParameterModel struct {
+	Name  string `tfschema:"name"`
+	Value string `tfschema:"value"`
+}
+
+type VirtualMachineRunCommandInstanceViewModel struct {
+	ExecutionState   string `tfschema:"execution_state"`
+	ExecutionMessage string `tfschema:"execution_message"`
+	ExitCode         int    `tfschema:"exit_code"`
+	Output           string `tfschema:"output"`
+	ErrorMessage     string `tfschema:"error_message"`
+	StartTime        string `tfschema:"start_time"`
+	EndTime          string `tfschema:"end_time"`
+}
+
+func (r VirtualMachineRunCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"virtual_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.VirtualMachineID,
+		},
+
+		"location": commonschema.Location(),
+
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"script": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.script", "source.0.script_uri", "source.0.command_id"},
+					},
+
+					// the Script URI will generally contain a SAS Token
+					"script_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.IsURLWithHTTPS,
+						ExactlyOneOf: []string{"source.0.script", "source.0.script_uri", "source.0.command_id"},
+					},
+
+					"command_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.script", "source.0.script_uri", "source.0.command_id"},
+					},
+				},
+			},
+		},
+
+		"parameter": virtualMachineRunCommandParameterSchema(false),
+
+		"protected_parameter": virtualMachineRunCommandParameterSchema(true),
+
+		"async_execution": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"run_as_user": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"run_as_password": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			RequiredWith: []string{"run_as_user"},
+		},
+
+		"timeout_in_seconds": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 5400),
+		},
+
+		"output_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"error_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"instance_view": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"execution_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"execution_message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"exit_code": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"output": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"error_message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"start_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"end_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) ModelObject() interface{} {
+	return &VirtualMachineRunCommandModel{}
+}
+
+func (r VirtualMachineRunCommandResource) ResourceType() string {
+	return "azurerm_virtual_machine_run_command"
+}
+
+func (r VirtualMachineRunCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.VirtualMachineRunCommandID
+}
+
+func (r VirtualMachineRunCommandResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			rd := metadata.ResourceDiff
+			if rd.Id() == "" {
+				return nil
+			}
+
+			// a Run Command which has failed (for example a non-zero exit code when `async_execution` is enabled)
+			// is surfaced as an update in the plan, which re-runs the script
+			switch state := rd.Get("instance_view.0.execution_state").(string); state {
+			case string(compute.ExecutionStateFailed), string(compute.ExecutionStateTimedOut), string(compute.ExecutionStateCanceled):
+				metadata.Logger.Warnf("the Run Command %q has an execution state of %q (exit code %d) and will be re-run", rd.Id(), state, rd.Get("instance_view.0.exit_code").(int))
+				return rd.SetNewComputed("instance_view")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VMRunCommandsClient
+
+			var model VirtualMachineRunCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			virtualMachineId, err := parse.VirtualMachineID(model.VirtualMachineId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewVirtualMachineRunCommandID(virtualMachineId.SubscriptionId, virtualMachineId.ResourceGroup, virtualMachineId.Name, model.Name)
+			existing, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, expandVirtualMachineRunCommand(model))
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			// the Run Command exists at this point even if the script then fails - so the ID is set prior to waiting
+			// in order for a failed Run Command to be tainted (and re-run) rather than requiring an import
+			metadata.SetID(id)
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VMRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "instanceView")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			var state VirtualMachineRunCommandModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model := VirtualMachineRunCommandModel{
+				Name:             id.RunCommandName,
+				VirtualMachineId: parse.NewVirtualMachineID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName).ID(),
+				Location:         location.NormalizeNilable(resp.Location),
+				Tags:             tags.ToTypedObject(resp.Tags),
+
+				// these aren't returned by the API, so we pull them from the config/state
+				ProtectedParameter: state.ProtectedParameter,
+				RunAsPassword:      state.RunAsPassword,
+			}
+
+			if props := resp.VirtualMachineRunCommandProperties; props != nil {
+				if props.AsyncExecution != nil {
+					model.AsyncExecution = *props.AsyncExecution
+				}
+				if props.RunAsUser != nil {
+					model.RunAsUser = *props.RunAsUser
+				}
+				if props.TimeoutInSeconds != nil {
+					model.TimeoutInSeconds = int(*props.TimeoutInSeconds)
+				}
+				if props.OutputBlobURI != nil {
+					model.OutputBlobUri = *props.OutputBlobURI
+				}
+				if props.ErrorBlobURI != nil {
+					model.ErrorBlobUri = *props.ErrorBlobURI
+				}
+
+				model.Source = flattenVirtualMachineRunCommandSource(props.Source)
+				model.Parameter = flattenVirtualMachineRunCommandParameters(props.Parameters)
+				model.InstanceView = flattenVirtualMachineRunCommandInstanceView(props.InstanceView)
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VMRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model VirtualMachineRunCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the Protected Parameters and Run As Password aren't returned by the API, so the whole Run Command
+			// is sent (which re-runs the script) rather than patching the changed fields
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, expandVirtualMachineRunCommand(model))
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VMRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func virtualMachineRunCommandParameterSchema(sensitive bool) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:      pluginsdk.TypeList,
+		Optional:  true,
+		Sensitive: sensitive,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"value": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					Sensitive:    sensitive,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineRunCommand(model VirtualMachineRunCommandModel) compute.VirtualMachineRunCommand {
+	props := &compute.VirtualMachineRunCommandProperties{
+		AsyncExecution:      utils.Bool(model.AsyncExecution),
+		Parameters:          expandVirtualMachineRunCommandParameters(model.Parameter),
+		ProtectedParameters: expandVirtualMachineRunCommandParameters(model.ProtectedParameter),
+		Source:              expandVirtualMachineRunCommandSource(model.Source),
+	}
+
+	if model.RunAsUser != "" {
+		props.RunAsUser = utils.String(model.RunAsUser)
+	}
+	if model.RunAsPassword != "" {
+		props.RunAsPassword = utils.String(model.RunAsPassword)
+	}
+	if model.TimeoutInSeconds > 0 {
+		props.TimeoutInSeconds = utils.Int32(int32(model.TimeoutInSeconds))
+	}
+	if model.OutputBlobUri != "" {
+		props.OutputBlobURI = utils.String(model.OutputBlobUri)
+	}
+	if model.ErrorBlobUri != "" {
+		props.ErrorBlobURI = utils.String(model.ErrorBlobUri)
+	}
+
+	return compute.VirtualMachineRunCommand{
+		Location:                           utils.String(location.Normalize(model.Location)),
+		VirtualMachineRunCommandProperties: props,
+		Tags:                               tags.FromTypedObject(model.Tags),
+	}
+}
+
+func expandVirtualMachineRunCommandSource(input []VirtualMachineRunCommandSourceModel) *compute.VirtualMachineRunCommandScriptSource {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := &compute.VirtualMachineRunCommandScriptSource{}
+	if input[0].Script != "" {
+		output.Script = utils.String(input[0].Script)
+	}
+	if input[0].ScriptUri != "" {
+		output.ScriptURI = utils.String(input[0].ScriptUri)
+	}
+	if input[0].CommandId != "" {
+		output.CommandID = utils.String(input[0].CommandId)
+	}
+	return output
+}
+
+func flattenVirtualMachineRunCommandSource(input *compute.VirtualMachineRunCommandScriptSource) []VirtualMachineRunCommandSourceModel {
+	if input == nil {
+		return []VirtualMachineRunCommandSourceModel{}
+	}
+
+	output := VirtualMachineRunCommandSourceModel{}
+	if input.Script != nil {
+		output.Script = *input.Script
+	}
+	if input.ScriptURI != nil {
+		output.ScriptUri = *input.ScriptURI
+	}
+	if input.CommandID != nil {
+		output.CommandId = *input.CommandID
+	}
+	return []VirtualMachineRunCommandSourceModel{output}
+}
+
+func expandVirtualMachineRunCommandParameters(input []VirtualMachineRunCommandParameterModel) *[]compute.RunCommandInputParameter {
+	output := make([]compute.RunCommandInputParameter, 0)
+	for _, v := range input {
+		output = append(output, compute.RunCommandInputParameter{
+			Name:  utils.String(v.Name),
+			Value: utils.String(v.Value),
+		})
+	}
+	return &output
+}
+
+func flattenVirtualMachineRunCommandParameters(input *[]compute.RunCommandInputParameter) []VirtualMachineRunCommandParameterModel {
+	output := make([]VirtualMachineRunCommandParameterModel, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		parameter := VirtualMachineRunCommandParameterModel{}
+		if v.Name != nil {
+			parameter.Name = *v.Name
+		}
+		if v.Value != nil {
+			parameter.Value = *v.Value
+		}
+		output = append(output, parameter)
+	}
+	return output
+}
+
+func flattenVirtualMachineRunCommandInstanceView(input *compute.VirtualMachineRunCommandInstanceView) []VirtualMachineRunCommandInstanceViewModel {
+	if input == nil {
+		return []VirtualMachineRunCommandInstanceViewModel{}
+	}
+
+	output := VirtualMachineRunCommandInstanceViewModel{
+		ExecutionState: string(input.ExecutionState),
+	}
+	if input.ExecutionMessage != nil {
+		output.ExecutionMessage = *input.ExecutionMessage
+	}
+	if input.ExitCode != nil {
+		output.ExitCode = int(*input.ExitCode)
+	}
+	if input.Output != nil {
+		output.Output = *input.Output
+	}
+	if input.Error != nil {
+		output.ErrorMessage = *input.Error
+	}
+	if input.StartTime != nil {
+		output.StartTime = input.StartTime.Format(time.RFC3339)
+	}
+	if input.EndTime != nil {
+		output.EndTime = input.EndTime.Format(time.RFC3339)
+	}
+	return []VirtualMachineRunCommandInstanceViewModel{output}
+}
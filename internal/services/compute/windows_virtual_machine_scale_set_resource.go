@@ -41,6 +41,8 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 			return err
 		}, importVirtualMachineScaleSet(compute.OperatingSystemTypesWindows, "azurerm_windows_virtual_machine_scale_set")),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineScaleSetSkuAvailabilityCustomizeDiff("sku", true)),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -297,10 +299,22 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 		virtualMachineProfile.ScheduledEventsProfile = ExpandVirtualMachineScaleSetScheduledEventsProfile(v.([]interface{}))
 	}
 
+	if v, ok := d.GetOk("capacity_reservation_group_id"); ok {
+		virtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{
+				ID: utils.String(v.(string)),
+			},
+		}
+	}
+
 	if v, ok := d.GetOk("user_data"); ok {
 		virtualMachineProfile.UserData = utils.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("gallery_application"); ok {
+		virtualMachineProfile.ApplicationProfile = expandVirtualMachineGalleryApplications(v.([]interface{}))
+	}
+
 	scaleInPolicy := d.Get("scale_in_policy").(string)
 	automaticRepairsPolicyRaw := d.Get("automatic_instance_repair").([]interface{})
 	automaticRepairsPolicy := ExpandVirtualMachineScaleSetAutomaticRepairsPolicy(automaticRepairsPolicyRaw)
@@ -810,6 +824,12 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 			return fmt.Errorf("setting `boot_diagnostics`: %+v", err)
 		}
 
+		capacityReservationGroupId := ""
+		if profile.CapacityReservation != nil && profile.CapacityReservation.CapacityReservationGroup != nil && profile.CapacityReservation.CapacityReservationGroup.ID != nil {
+			capacityReservationGroupId = *profile.CapacityReservation.CapacityReservationGroup.ID
+		}
+		d.Set("capacity_reservation_group_id", capacityReservationGroupId)
+
 		// defaulted since BillingProfile isn't returned if it's unset
 		maxBidPrice := float64(-1.0)
 		if profile.BillingProfile != nil && profile.BillingProfile.MaxPrice != nil {
@@ -922,6 +942,10 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 		}
 		d.Set("extensions_time_budget", extensionsTimeBudget)
 
+		if err := d.Set("gallery_application", flattenVirtualMachineGalleryApplications(profile.ApplicationProfile)); err != nil {
+			return fmt.Errorf("setting `gallery_application`: %+v", err)
+		}
+
 		encryptionAtHostEnabled := false
 		vtpmEnabled := false
 		secureBootEnabled := false
@@ -1073,6 +1097,18 @@ func resourceWindowsVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema
 
 		"boot_diagnostics": bootDiagnosticsSchema(),
 
+		"capacity_reservation_group_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: computeValidate.CapacityReservationGroupID,
+			// the Compute API is broken and returns the Resource Group name in UPPERCASE
+			DiffSuppressFunc: suppress.CaseDifference,
+			ConflictsWith: []string{
+				"proximity_placement_group_id",
+			},
+		},
+
 		"computer_name_prefix": {
 			Type:     pluginsdk.TypeString,
 			Optional: true,
@@ -1134,6 +1170,8 @@ func resourceWindowsVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema
 			ValidateFunc: azure.ValidateResourceID,
 		},
 
+		"gallery_application": VirtualMachineScaleSetGalleryApplicationSchema(),
+
 		"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),
 
 		"license_type": {
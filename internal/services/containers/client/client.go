@@ -5,7 +5,7 @@ import (
 	legacy "github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-08-01/containerservice"
 	legacyacr "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2019-06-01-preview/containerregistry"
 	"github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2021-08-01-preview/containerregistry"
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
@@ -19,6 +19,7 @@ type Client struct {
 	RegistriesClient                  *containerregistry.RegistriesClient
 	ReplicationsClient                *containerregistry.ReplicationsClient
 	ServicesClient                    *legacy.ContainerServicesClient
+	SnapshotsClient                   *containerservice.SnapshotsClient
 	WebhooksClient                    *containerregistry.WebhooksClient
 	TokensClient                      *containerregistry.TokensClient
 	ScopeMapsClient                   *containerregistry.ScopeMapsClient
@@ -63,6 +64,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	maintenanceConfigurationsClient := containerservice.NewMaintenanceConfigurationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&maintenanceConfigurationsClient.Client, o.ResourceManagerAuthorizer)
 
+	snapshotsClient := containerservice.NewSnapshotsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&snapshotsClient.Client, o.ResourceManagerAuthorizer)
+
 	servicesClient := legacy.NewContainerServicesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&servicesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -79,6 +83,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		WebhooksClient:                    &webhooksClient,
 		ReplicationsClient:                &replicationsClient,
 		ServicesClient:                    &servicesClient,
+		SnapshotsClient:                   &snapshotsClient,
 		Environment:                       o.Environment,
 		TokensClient:                      &tokensClient,
 		ScopeMapsClient:                   &scopeMapsClient,
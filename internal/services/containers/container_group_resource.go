@@ -657,7 +657,9 @@ func resourceContainerGroupCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		networkProfileIDNorm := id.ID()
 		// Avoid parallel provisioning if "network_profile_id" is given.
 		// See: https://github.com/hashicorp/terraform-provider-azurerm/issues/15025
-		locks.ByID(networkProfileIDNorm)
+		if err := locks.ByID(ctx, networkProfileIDNorm); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByID(networkProfileIDNorm)
 
 		if strings.ToLower(OSType) != "linux" {
@@ -866,7 +868,9 @@ func resourceContainerGroupDelete(d *pluginsdk.ResourceData, meta interface{}) e
 			}
 			// Avoid parallel deletion if "network_profile_id" is given. (not sure whether this is necessary)
 			// See: https://github.com/hashicorp/terraform-provider-azurerm/issues/15025
-			locks.ByID(networkProfileId)
+			if err := locks.ByID(ctx, networkProfileId); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(networkProfileId)
 		}
 	}
@@ -24,8 +24,9 @@ import (
 type ContainerRegistryTaskResource struct{}
 
 var (
-	_ sdk.ResourceWithUpdate        = ContainerRegistryTaskResource{}
-	_ sdk.ResourceWithCustomizeDiff = ContainerRegistryTaskResource{}
+	_ sdk.ResourceWithUpdate               = ContainerRegistryTaskResource{}
+	_ sdk.ResourceWithCustomizeDiff        = ContainerRegistryTaskResource{}
+	_ sdk.ResourceWithSchemaKeysNotInModel = ContainerRegistryTaskResource{}
 )
 
 type AgentConfig struct {
@@ -650,6 +651,11 @@ func (r ContainerRegistryTaskResource) ModelObject() interface{} {
 	return &ContainerRegistryTaskModel{}
 }
 
+func (r ContainerRegistryTaskResource) SchemaKeysNotInModel() []string {
+	// the identity is expanded/flattened using the ResourceData directly
+	return []string{"identity"}
+}
+
 func (r ContainerRegistryTaskResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
 	return validate.ContainerRegistryTaskID
 }
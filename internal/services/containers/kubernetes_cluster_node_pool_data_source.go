@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -133,6 +133,11 @@ func dataSourceKubernetesClusterNodePool() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"snapshot_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"spot_max_price": {
 				Type:     pluginsdk.TypeFloat,
 				Computed: true,
@@ -270,6 +275,16 @@ func dataSourceKubernetesClusterNodePoolRead(d *pluginsdk.ResourceData, meta int
 		}
 		d.Set("proximity_placement_group_id", proximityPlacementGroupId)
 
+		snapshotId := ""
+		if props.CreationData != nil && props.CreationData.SourceResourceID != nil {
+			snapshot, err := parse.NodePoolSnapshotID(*props.CreationData.SourceResourceID)
+			if err != nil {
+				return err
+			}
+			snapshotId = snapshot.ID()
+		}
+		d.Set("snapshot_id", snapshotId)
+
 		spotMaxPrice := -1.0
 		if props.SpotMaxPrice != nil {
 			spotMaxPrice = *props.SpotMaxPrice
@@ -6,7 +6,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -258,6 +258,13 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 				ValidateFunc: computeValidate.ProximityPlacementGroupID,
 			},
 
+			"snapshot_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: containerValidate.NodePoolSnapshotID,
+			},
+
 			"spot_max_price": {
 				Type:         pluginsdk.TypeFloat,
 				Optional:     true,
@@ -449,6 +456,12 @@ func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta int
 		profile.ProximityPlacementGroupID = &proximityPlacementGroupId
 	}
 
+	if snapshotId := d.Get("snapshot_id").(string); snapshotId != "" {
+		profile.CreationData = &containerservice.CreationData{
+			SourceResourceID: utils.String(snapshotId),
+		}
+	}
+
 	if osDiskType := d.Get("os_disk_type").(string); osDiskType != "" {
 		profile.OsDiskType = containerservice.OSDiskType(osDiskType)
 	}
@@ -819,6 +832,16 @@ func resourceKubernetesClusterNodePoolRead(d *pluginsdk.ResourceData, meta inter
 
 		d.Set("proximity_placement_group_id", props.ProximityPlacementGroupID)
 
+		snapshotId := ""
+		if props.CreationData != nil && props.CreationData.SourceResourceID != nil {
+			snapshot, err := parse.NodePoolSnapshotID(*props.CreationData.SourceResourceID)
+			if err != nil {
+				return err
+			}
+			snapshotId = snapshot.ID()
+		}
+		d.Set("snapshot_id", snapshotId)
+
 		spotMaxPrice := -1.0
 		if props.SpotMaxPrice != nil {
 			spotMaxPrice = *props.SpotMaxPrice
@@ -846,7 +869,7 @@ func resourceKubernetesClusterNodePoolDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName)
+	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName, nil)
 	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
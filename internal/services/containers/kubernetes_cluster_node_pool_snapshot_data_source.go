@@ -0,0 +1,99 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.DataSource = KubernetesClusterNodePoolSnapshotDataSource{}
+
+type KubernetesClusterNodePoolSnapshotDataSource struct{}
+
+func (r KubernetesClusterNodePoolSnapshotDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotDataSource) Attributes() map[string]*pluginsdk.Schema {
+	s := nodePoolSnapshotComputedSchema()
+	s["location"] = commonschema.LocationComputed()
+	s["source_node_pool_id"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Computed: true,
+	}
+	s["tags"] = commonschema.TagsDataSource()
+	return s
+}
+
+func (r KubernetesClusterNodePoolSnapshotDataSource) ModelObject() interface{} {
+	return &KubernetesClusterNodePoolSnapshotModel{}
+}
+
+func (r KubernetesClusterNodePoolSnapshotDataSource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_node_pool_snapshot"
+}
+
+func (r KubernetesClusterNodePoolSnapshotDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var state KubernetesClusterNodePoolSnapshotModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewNodePoolSnapshotID(subscriptionId, state.ResourceGroup, state.Name)
+			resp, err := client.Get(ctx, id.ResourceGroup, id.SnapshotName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := KubernetesClusterNodePoolSnapshotModel{
+				Name:          id.SnapshotName,
+				ResourceGroup: id.ResourceGroup,
+				Location:      location.NormalizeNilable(resp.Location),
+				Tags:          tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.SnapshotProperties; props != nil {
+				sourceNodePoolId, err := flattenNodePoolSnapshotSourceId(props.CreationData)
+				if err != nil {
+					return err
+				}
+				model.SourceNodePoolId = sourceNodePoolId
+				model.KubernetesVersion = utils.NormalizeNilableString(props.KubernetesVersion)
+				model.NodeImageVersion = utils.NormalizeNilableString(props.NodeImageVersion)
+				model.OsType = string(props.OsType)
+				model.OsSku = string(props.OsSku)
+				model.VmSize = utils.NormalizeNilableString(props.VMSize)
+				model.FipsEnabled = props.EnableFIPS != nil && *props.EnableFIPS
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&model)
+		},
+	}
+}
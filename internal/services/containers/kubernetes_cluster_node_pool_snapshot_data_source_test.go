@@ -0,0 +1,39 @@
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KubernetesClusterNodePoolSnapshotDataSource struct{}
+
+func TestAccKubernetesClusterNodePoolSnapshotDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_cluster_node_pool_snapshot", "test")
+	r := KubernetesClusterNodePoolSnapshotDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("source_node_pool_id").Exists(),
+				check.That(data.ResourceName).Key("node_image_version").Exists(),
+				check.That(data.ResourceName).Key("vm_size").HasValue("Standard_DS2_v2"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("Production"),
+			),
+		},
+	})
+}
+
+func (KubernetesClusterNodePoolSnapshotDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_node_pool_snapshot" "test" {
+  name                = azurerm_kubernetes_cluster_node_pool_snapshot.test.name
+  resource_group_name = azurerm_kubernetes_cluster_node_pool_snapshot.test.resource_group_name
+}
+`, KubernetesClusterNodePoolSnapshotResource{}.tags(data, "Production"))
+}
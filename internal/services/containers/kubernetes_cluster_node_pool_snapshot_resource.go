@@ -0,0 +1,263 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = KubernetesClusterNodePoolSnapshotResource{}
+
+type KubernetesClusterNodePoolSnapshotResource struct{}
+
+type KubernetesClusterNodePoolSnapshotModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroup     string            `tfschema:"resource_group_name"`
+	Location          string            `tfschema:"location"`
+	SourceNodePoolId  string            `tfschema:"source_node_pool_id"`
+	Tags              map[string]string `tfschema:"tags"`
+	KubernetesVersion string            `tfschema:"kubernetes_version"`
+	NodeImageVersion  string            `tfschema:"node_image_version"`
+	OsType            string            `tfschema:"os_type"`
+	OsSku             string            `tfschema:"os_sku"`
+	VmSize            string            `tfschema:"vm_size"`
+	FipsEnabled       bool              `tfschema:"fips_enabled"`
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"source_node_pool_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.NodePoolID,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Attributes() map[string]*pluginsdk.Schema {
+	return nodePoolSnapshotComputedSchema()
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) ModelObject() interface{} {
+	return &KubernetesClusterNodePoolSnapshotModel{}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_node_pool_snapshot"
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.NodePoolSnapshotID
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model KubernetesClusterNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewNodePoolSnapshotID(subscriptionId, model.ResourceGroup, model.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.SnapshotName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := containerservice.Snapshot{
+				Location: utils.String(location.Normalize(model.Location)),
+				SnapshotProperties: &containerservice.SnapshotProperties{
+					CreationData: &containerservice.CreationData{
+						SourceResourceID: utils.String(model.SourceNodePoolId),
+					},
+					SnapshotType: containerservice.SnapshotTypeNodePool,
+				},
+				Tags: tags.FromTypedObject(model.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.SnapshotName, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := parse.NodePoolSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.SnapshotName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			model := KubernetesClusterNodePoolSnapshotModel{
+				Name:          id.SnapshotName,
+				ResourceGroup: id.ResourceGroup,
+				Location:      location.NormalizeNilable(resp.Location),
+				Tags:          tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.SnapshotProperties; props != nil {
+				sourceNodePoolId, err := flattenNodePoolSnapshotSourceId(props.CreationData)
+				if err != nil {
+					return err
+				}
+				model.SourceNodePoolId = sourceNodePoolId
+				model.KubernetesVersion = utils.NormalizeNilableString(props.KubernetesVersion)
+				model.NodeImageVersion = utils.NormalizeNilableString(props.NodeImageVersion)
+				model.OsType = string(props.OsType)
+				model.OsSku = string(props.OsSku)
+				model.VmSize = utils.NormalizeNilableString(props.VMSize)
+				model.FipsEnabled = props.EnableFIPS != nil && *props.EnableFIPS
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := parse.NodePoolSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesClusterNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters := containerservice.TagsObject{
+					Tags: tags.FromTypedObject(model.Tags),
+				}
+				if _, err := client.UpdateTags(ctx, id.ResourceGroup, id.SnapshotName, parameters); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := parse.NodePoolSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if resp, err := client.Delete(ctx, id.ResourceGroup, id.SnapshotName); err != nil {
+				if !utils.ResponseWasNotFound(resp) {
+					return fmt.Errorf("deleting %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// nodePoolSnapshotComputedSchema returns the attributes describing the Node Pool captured in a Snapshot, which
+// are exported from both the Resource and the Data Source
+func nodePoolSnapshotComputedSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"node_image_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_sku": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"vm_size": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"fips_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func flattenNodePoolSnapshotSourceId(input *containerservice.CreationData) (string, error) {
+	if input == nil || input.SourceResourceID == nil {
+		return "", nil
+	}
+
+	nodePoolId, err := parse.NodePoolID(*input.SourceResourceID)
+	if err != nil {
+		return "", fmt.Errorf("parsing `creationData.sourceResourceId`: %+v", err)
+	}
+	return nodePoolId.ID(), nil
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
@@ -51,6 +51,8 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("service_principal.0.client_id", func(ctx context.Context, old, new, meta interface{}) bool {
 				return old == "msi" || old == ""
 			}),
+			validateKubernetesClusterWorkloadIdentity,
+			validateKubernetesClusterUpgradePath,
 		),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
@@ -238,6 +240,21 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 
 			"identity": commonschema.SystemOrUserAssignedIdentityOptional(),
 
+			"key_management_service": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key_vault_key_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: containerValidate.KubernetesKeyManagementServiceKeyID,
+						},
+					},
+				},
+			},
+
 			"kubelet_identity": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -629,6 +646,12 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"workload_identity_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"private_fqdn": { // privateFqdn
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -805,6 +828,8 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"upgrade_orchestration": kubernetesClusterUpgradeOrchestrationSchema(),
+
 			"windows_profile": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -1105,7 +1130,21 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 	}
 
 	microsoftDefenderRaw := d.Get("microsoft_defender").([]interface{})
-	microsoftDefender := expandKubernetesClusterMicrosoftDefender(d, microsoftDefenderRaw)
+	securityProfile := expandKubernetesClusterMicrosoftDefender(d, microsoftDefenderRaw)
+
+	if d.Get("workload_identity_enabled").(bool) {
+		if securityProfile == nil {
+			securityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		}
+		securityProfile.WorkloadIdentity = expandKubernetesClusterWorkloadIdentity(true)
+	}
+
+	if keyManagementServiceRaw := d.Get("key_management_service").([]interface{}); len(keyManagementServiceRaw) > 0 {
+		if securityProfile == nil {
+			securityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		}
+		securityProfile.AzureKeyVaultKms = expandKubernetesClusterKeyManagementService(keyManagementServiceRaw)
+	}
 
 	parameters := containerservice.ManagedCluster{
 		Name:     utils.String(id.ManagedClusterName),
@@ -1131,7 +1170,7 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 			DisableLocalAccounts:   utils.Bool(d.Get("local_account_disabled").(bool)),
 			HTTPProxyConfig:        httpProxyConfig,
 			OidcIssuerProfile:      oidcIssuerProfile,
-			SecurityProfile:        microsoftDefender,
+			SecurityProfile:        securityProfile,
 		},
 		Tags: tags.Expand(t),
 	}
@@ -1569,20 +1608,41 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		existing.ManagedClusterProperties.OidcIssuerProfile = oidcIssuerProfile
 	}
 
-	if d.HasChanges("microsoft_defender") {
+	if d.HasChanges("microsoft_defender", "workload_identity_enabled", "key_management_service") {
 		updateCluster = true
-		microsoftDefenderRaw := d.Get("microsoft_defender").([]interface{})
-		microsoftDefender := expandKubernetesClusterMicrosoftDefender(d, microsoftDefenderRaw)
-		existing.ManagedClusterProperties.SecurityProfile = microsoftDefender
 	}
 
 	if updateCluster {
-		// If Defender was explicitly disabled in a prior update then we should strip security profile from the request
-		// body to prevent errors in cases where Defender is disabled for the entire subscription
-		if !d.HasChanges("microsoft_defender") && len(d.Get("microsoft_defender").([]interface{})) == 0 {
-			existing.ManagedClusterProperties.SecurityProfile = nil
+		// the Security Profile is built from the existing profile, such that only the features which have changed
+		// are enabled/disabled in-place and the others are sent unchanged
+		securityProfile := existing.ManagedClusterProperties.SecurityProfile
+		if securityProfile == nil {
+			securityProfile = &containerservice.ManagedClusterSecurityProfile{}
 		}
 
+		if d.HasChange("microsoft_defender") {
+			microsoftDefenderRaw := d.Get("microsoft_defender").([]interface{})
+			securityProfile.AzureDefender = expandKubernetesClusterMicrosoftDefender(d, microsoftDefenderRaw).AzureDefender
+		} else if len(d.Get("microsoft_defender").([]interface{})) == 0 {
+			// If Defender was explicitly disabled in a prior update then we should strip it from the request
+			// body to prevent errors in cases where Defender is disabled for the entire subscription
+			securityProfile.AzureDefender = nil
+		}
+
+		if d.HasChange("workload_identity_enabled") {
+			securityProfile.WorkloadIdentity = expandKubernetesClusterWorkloadIdentity(d.Get("workload_identity_enabled").(bool))
+		}
+
+		if d.HasChange("key_management_service") {
+			keyManagementServiceRaw := d.Get("key_management_service").([]interface{})
+			securityProfile.AzureKeyVaultKms = expandKubernetesClusterKeyManagementService(keyManagementServiceRaw)
+		}
+
+		if securityProfile.AzureDefender == nil && securityProfile.WorkloadIdentity == nil && securityProfile.AzureKeyVaultKms == nil {
+			securityProfile = nil
+		}
+		existing.ManagedClusterProperties.SecurityProfile = securityProfile
+
 		log.Printf("[DEBUG] Updating %s..", *id)
 		future, err := clusterClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, existing)
 		if err != nil {
@@ -1595,27 +1655,15 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		log.Printf("[DEBUG] Updated %s..", *id)
 	}
 
-	// then roll the version of Kubernetes if necessary
+	// then roll the version of Kubernetes if necessary, starting with the Control Plane followed by the Node Pools
+	upgradedNodePools := make(map[string]struct{})
 	if d.HasChange("kubernetes_version") {
-		existing, err = clusterClient.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
-		if err != nil {
-			return fmt.Errorf("retrieving existing %s: %+v", *id, err)
-		}
-		if existing.ManagedClusterProperties == nil {
-			return fmt.Errorf("retrieving existing %s: `properties` was nil", *id)
-		}
-
 		kubernetesVersion := d.Get("kubernetes_version").(string)
 		log.Printf("[DEBUG] Upgrading the version of Kubernetes to %q..", kubernetesVersion)
-		existing.ManagedClusterProperties.KubernetesVersion = utils.String(kubernetesVersion)
 
-		future, err := clusterClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, existing)
+		upgradedNodePools, err = upgradeKubernetesCluster(ctx, d, containersClient, *id, kubernetesVersion)
 		if err != nil {
-			return fmt.Errorf("updating Kubernetes Version for %s: %+v", *id, err)
-		}
-
-		if err = future.WaitForCompletionRef(ctx, clusterClient.Client); err != nil {
-			return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+			return err
 		}
 
 		log.Printf("[DEBUG] Upgraded the version of Kubernetes to %q..", kubernetesVersion)
@@ -1633,6 +1681,11 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		agentProfile := ConvertDefaultNodePoolToAgentPool(agentProfiles)
 		defaultNodePoolId := parse.NewNodePoolID(id.SubscriptionId, id.ResourceGroup, id.ManagedClusterName, *agentProfile.Name)
 
+		// the computed `orchestrator_version` is outdated when the Default Node Pool was upgraded above
+		if _, ok := upgradedNodePools[strings.ToLower(*agentProfile.Name)]; ok {
+			agentProfile.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion = utils.String(d.Get("kubernetes_version").(string))
+		}
+
 		// if a users specified a version - confirm that version is supported on the cluster
 		if nodePoolVersion := agentProfile.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion; nodePoolVersion != nil {
 			existingNodePool, err := nodePoolsClient.Get(ctx, defaultNodePoolId.ResourceGroup, defaultNodePoolId.ManagedClusterName, defaultNodePoolId.AgentPoolName)
@@ -1849,6 +1902,12 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("setting `microsoft_defender`: %+v", err)
 		}
 
+		d.Set("workload_identity_enabled", flattenKubernetesClusterWorkloadIdentity(props.SecurityProfile))
+
+		if err := d.Set("key_management_service", flattenKubernetesClusterKeyManagementService(props.SecurityProfile)); err != nil {
+			return fmt.Errorf("setting `key_management_service`: %+v", err)
+		}
+
 		// adminProfile is only available for RBAC enabled clusters with AAD and local account is not disabled
 		if props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) {
 			adminProfile, err := client.GetAccessProfile(ctx, id.ResourceGroup, id.ManagedClusterName, "clusterAdmin")
@@ -1908,7 +1967,7 @@ func resourceKubernetesClusterDelete(d *pluginsdk.ResourceData, meta interface{}
 		}
 	}
 
-	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedClusterName)
+	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedClusterName, nil)
 	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
@@ -2991,3 +3050,49 @@ func flattenKubernetesClusterMicrosoftDefender(input *containerservice.ManagedCl
 		},
 	}
 }
+
+func expandKubernetesClusterWorkloadIdentity(enabled bool) *containerservice.ManagedClusterSecurityProfileWorkloadIdentity {
+	return &containerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+		Enabled: utils.Bool(enabled),
+	}
+}
+
+func flattenKubernetesClusterWorkloadIdentity(input *containerservice.ManagedClusterSecurityProfile) bool {
+	if input == nil || input.WorkloadIdentity == nil || input.WorkloadIdentity.Enabled == nil {
+		return false
+	}
+
+	return *input.WorkloadIdentity.Enabled
+}
+
+func expandKubernetesClusterKeyManagementService(input []interface{}) *containerservice.AzureKeyVaultKms {
+	// the Key ID must be omitted when disabling the Key Management Service
+	if len(input) == 0 || input[0] == nil {
+		return &containerservice.AzureKeyVaultKms{
+			Enabled: utils.Bool(false),
+		}
+	}
+
+	config := input[0].(map[string]interface{})
+	return &containerservice.AzureKeyVaultKms{
+		Enabled: utils.Bool(true),
+		KeyID:   utils.String(config["key_vault_key_id"].(string)),
+	}
+}
+
+func flattenKubernetesClusterKeyManagementService(input *containerservice.ManagedClusterSecurityProfile) []interface{} {
+	if input == nil || input.AzureKeyVaultKms == nil || input.AzureKeyVaultKms.Enabled == nil || !*input.AzureKeyVaultKms.Enabled {
+		return []interface{}{}
+	}
+
+	keyId := ""
+	if v := input.AzureKeyVaultKms.KeyID; v != nil {
+		keyId = *v
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"key_vault_key_id": keyId,
+		},
+	}
+}
@@ -0,0 +1,412 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func kubernetesClusterUpgradeOrchestrationSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"node_pool_order": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"max_surge": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+type kubernetesClusterUpgradeOrchestration struct {
+	nodePoolOrder []string
+	maxSurge      string
+}
+
+// expandKubernetesClusterUpgradeOrchestration returns nil when the `upgrade_orchestration` block isn't specified,
+// in which case only the Control Plane is upgraded when the `kubernetes_version` changes
+func expandKubernetesClusterUpgradeOrchestration(input []interface{}) *kubernetesClusterUpgradeOrchestration {
+	if len(input) == 0 {
+		return nil
+	}
+
+	orchestration := kubernetesClusterUpgradeOrchestration{}
+	if input[0] == nil {
+		return &orchestration
+	}
+
+	raw := input[0].(map[string]interface{})
+	orchestration.nodePoolOrder = *utils.ExpandStringSlice(raw["node_pool_order"].([]interface{}))
+	orchestration.maxSurge = raw["max_surge"].(string)
+	return &orchestration
+}
+
+// upgradeKubernetesCluster upgrades the Control Plane to the specified version of Kubernetes, followed by
+// the Node Pools listed in `node_pool_order` and the Default Node Pool (when `upgrade_orchestration` is
+// specified) one at a time - returning the names of the Node Pools which were upgraded.
+//
+// Other Node Pools aren't upgraded, since these are managed using the `azurerm_kubernetes_cluster_node_pool`
+// resource - which would otherwise plan to downgrade them when their `orchestrator_version` is specified.
+//
+// Each step is skipped when it's already been completed and waits for an in-progress upgrade to finish, such
+// that a subsequent apply resumes an upgrade which timed out (or failed) partway through.
+func upgradeKubernetesCluster(ctx context.Context, d *pluginsdk.ResourceData, containersClient *client.Client, id parse.ClusterId, kubernetesVersion string) (map[string]struct{}, error) {
+	clusterClient := containersClient.KubernetesClustersClient
+	orchestration := expandKubernetesClusterUpgradeOrchestration(d.Get("upgrade_orchestration").([]interface{}))
+
+	existing, err := waitForKubernetesClusterToFinishProvisioning(ctx, containersClient, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if props := existing.ManagedClusterProperties; props != nil && kubernetesClusterIsRunningVersion(props.KubernetesVersion, props.CurrentKubernetesVersion, kubernetesVersion) {
+		log.Printf("[INFO] The Control Plane for %s is already running Kubernetes %q - skipping", id, kubernetesVersion)
+	} else {
+		log.Printf("[INFO] Upgrading the Control Plane for %s to Kubernetes %q..", id, kubernetesVersion)
+		existing.ManagedClusterProperties.KubernetesVersion = utils.String(kubernetesVersion)
+
+		// pinning the Node Pools to the version they're currently running ensures that only the Control Plane
+		// is upgraded, the Node Pools are then upgraded separately below
+		if profiles := existing.ManagedClusterProperties.AgentPoolProfiles; profiles != nil {
+			for i, profile := range *profiles {
+				if profile.CurrentOrchestratorVersion != nil {
+					(*profiles)[i].OrchestratorVersion = profile.CurrentOrchestratorVersion
+				}
+			}
+		}
+
+		// when update, we should set the value of `Identity.UserAssignedIdentities` empty
+		// otherwise the rest api will report error - this is tracked here: https://github.com/Azure/azure-rest-api-specs/issues/13631
+		if existing.Identity != nil && existing.Identity.UserAssignedIdentities != nil {
+			for k := range existing.Identity.UserAssignedIdentities {
+				existing.Identity.UserAssignedIdentities[k] = &containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{}
+			}
+		}
+
+		future, err := clusterClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, *existing)
+		if err != nil {
+			return nil, fmt.Errorf("upgrading the Control Plane for %s to Kubernetes %q: %+v", id, kubernetesVersion, err)
+		}
+		if err = future.WaitForCompletionRef(ctx, clusterClient.Client); err != nil {
+			return nil, fmt.Errorf("waiting for the Control Plane for %s to be upgraded to Kubernetes %q: %+v", id, kubernetesVersion, err)
+		}
+		log.Printf("[INFO] Upgraded the Control Plane for %s to Kubernetes %q", id, kubernetesVersion)
+	}
+
+	upgraded := make(map[string]struct{})
+	if orchestration == nil {
+		return upgraded, nil
+	}
+
+	nodePoolNames, err := kubernetesClusterNodePoolUpgradeOrder(ctx, containersClient, id, orchestration.nodePoolOrder, d.Get("default_node_pool.0.name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	// the Default Node Pool is upgraded separately when its `orchestrator_version` is specified in the config
+	defaultNodePoolName := d.Get("default_node_pool.0.name").(string)
+	if kubernetesClusterDefaultNodePoolVersionIsConfigured(d) {
+		log.Printf("[DEBUG] The `orchestrator_version` for the Default Node Pool %q is specified - excluding it from the upgrade", defaultNodePoolName)
+		for i, name := range nodePoolNames {
+			if strings.EqualFold(name, defaultNodePoolName) {
+				nodePoolNames = append(nodePoolNames[:i], nodePoolNames[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for i, name := range nodePoolNames {
+		nodePoolId := parse.NewNodePoolID(id.SubscriptionId, id.ResourceGroup, id.ManagedClusterName, name)
+		log.Printf("[INFO] Upgrading Node Pool %d of %d (%s) to Kubernetes %q..", i+1, len(nodePoolNames), nodePoolId, kubernetesVersion)
+		if err := upgradeKubernetesClusterNodePool(ctx, containersClient, nodePoolId, kubernetesVersion, orchestration.maxSurge); err != nil {
+			return nil, err
+		}
+		upgraded[strings.ToLower(name)] = struct{}{}
+		log.Printf("[INFO] Upgraded Node Pool %d of %d (%s) to Kubernetes %q", i+1, len(nodePoolNames), nodePoolId, kubernetesVersion)
+	}
+
+	return upgraded, nil
+}
+
+func upgradeKubernetesClusterNodePool(ctx context.Context, containersClient *client.Client, id parse.NodePoolId, kubernetesVersion, maxSurge string) error {
+	nodePoolsClient := containersClient.AgentPoolsClient
+
+	existing, err := waitForKubernetesClusterNodePoolToFinishProvisioning(ctx, containersClient, id)
+	if err != nil {
+		return err
+	}
+	if existing.ManagedClusterAgentPoolProfileProperties == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	props := existing.ManagedClusterAgentPoolProfileProperties
+	if kubernetesClusterIsRunningVersion(props.OrchestratorVersion, props.CurrentOrchestratorVersion, kubernetesVersion) {
+		log.Printf("[INFO] %s is already running Kubernetes %q - skipping", id, kubernetesVersion)
+		return nil
+	}
+
+	// the `max_surge` from `upgrade_orchestration` is only used for Node Pools which don't specify one, and is
+	// only applied for the duration of the upgrade so that the Node Pool doesn't drift from its configuration
+	upgradeSettings := props.UpgradeSettings
+	overrideMaxSurge := maxSurge != "" && (upgradeSettings == nil || upgradeSettings.MaxSurge == nil || *upgradeSettings.MaxSurge == "")
+
+	props.OrchestratorVersion = utils.String(kubernetesVersion)
+	if overrideMaxSurge {
+		log.Printf("[DEBUG] Using a `max_surge` of %q whilst upgrading %s", maxSurge, id)
+		props.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{
+			MaxSurge: utils.String(maxSurge),
+		}
+	}
+
+	future, err := nodePoolsClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName, existing)
+	if err != nil {
+		return fmt.Errorf("upgrading %s to Kubernetes %q: %+v", id, kubernetesVersion, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, nodePoolsClient.Client); err != nil {
+		return fmt.Errorf("waiting for %s to be upgraded to Kubernetes %q: %+v", id, kubernetesVersion, err)
+	}
+
+	if overrideMaxSurge {
+		if err := restoreKubernetesClusterNodePoolUpgradeSettings(ctx, containersClient, id, upgradeSettings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreKubernetesClusterNodePoolUpgradeSettings resets the Upgrade Settings for the Node Pool to those it had
+// prior to the upgrade - the Node Pool is retrieved again so that only the Upgrade Settings are changed
+func restoreKubernetesClusterNodePoolUpgradeSettings(ctx context.Context, containersClient *client.Client, id parse.NodePoolId, upgradeSettings *containerservice.AgentPoolUpgradeSettings) error {
+	nodePoolsClient := containersClient.AgentPoolsClient
+
+	existing, err := waitForKubernetesClusterNodePoolToFinishProvisioning(ctx, containersClient, id)
+	if err != nil {
+		return err
+	}
+	if existing.ManagedClusterAgentPoolProfileProperties == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	if upgradeSettings == nil {
+		upgradeSettings = &containerservice.AgentPoolUpgradeSettings{}
+	}
+	existing.ManagedClusterAgentPoolProfileProperties.UpgradeSettings = upgradeSettings
+
+	future, err := nodePoolsClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName, existing)
+	if err != nil {
+		return fmt.Errorf("restoring the Upgrade Settings for %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, nodePoolsClient.Client); err != nil {
+		return fmt.Errorf("waiting for the Upgrade Settings for %s to be restored: %+v", id, err)
+	}
+
+	resp, err := nodePoolsClient.Get(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	var current *containerservice.AgentPoolUpgradeSettings
+	if props := resp.ManagedClusterAgentPoolProfileProperties; props != nil {
+		current = props.UpgradeSettings
+	}
+	if !kubernetesClusterNodePoolMaxSurgeMatches(upgradeSettings, current) {
+		return fmt.Errorf("the `max_surge` for %s was not restored after the upgrade - expected %q but got %q", id, kubernetesClusterNodePoolMaxSurge(upgradeSettings), kubernetesClusterNodePoolMaxSurge(current))
+	}
+
+	return nil
+}
+
+// kubernetesClusterNodePoolMaxSurgeMatches returns whether the `max_surge` within the Upgrade Settings returned by the
+// API matches the expected Upgrade Settings, where an unset `max_surge` is equivalent to an empty one
+func kubernetesClusterNodePoolMaxSurgeMatches(expected, actual *containerservice.AgentPoolUpgradeSettings) bool {
+	return kubernetesClusterNodePoolMaxSurge(expected) == kubernetesClusterNodePoolMaxSurge(actual)
+}
+
+func kubernetesClusterNodePoolMaxSurge(input *containerservice.AgentPoolUpgradeSettings) string {
+	if input == nil || input.MaxSurge == nil {
+		return ""
+	}
+	return *input.MaxSurge
+}
+
+// kubernetesClusterNodePoolUpgradeOrder returns the names of the Node Pools within the Cluster which should be upgraded,
+// in the order they should be upgraded - those listed in `node_pool_order` (in that order), followed by the Default
+// Node Pool when it's specified and not already listed
+func kubernetesClusterNodePoolUpgradeOrder(ctx context.Context, containersClient *client.Client, id parse.ClusterId, order []string, defaultNodePoolName string) ([]string, error) {
+	nodePools, err := containersClient.AgentPoolsClient.ListComplete(ctx, id.ResourceGroup, id.ManagedClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("listing Node Pools for %s: %+v", id, err)
+	}
+
+	nodePoolNames := make([]string, 0)
+	for nodePools.NotDone() {
+		if v := nodePools.Value().Name; v != nil {
+			nodePoolNames = append(nodePoolNames, *v)
+		}
+		if err := nodePools.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Node Pools for %s: %+v", id, err)
+		}
+	}
+
+	return sortKubernetesClusterNodePoolsForUpgrade(id, nodePoolNames, order, defaultNodePoolName)
+}
+
+// sortKubernetesClusterNodePoolsForUpgrade returns the Node Pools which should be upgraded in the order they should
+// be upgraded, see kubernetesClusterNodePoolUpgradeOrder
+func sortKubernetesClusterNodePoolsForUpgrade(id parse.ClusterId, nodePoolNames []string, order []string, defaultNodePoolName string) ([]string, error) {
+	remaining := make(map[string]string)
+	for _, v := range nodePoolNames {
+		remaining[strings.ToLower(v)] = v
+	}
+
+	names := make([]string, 0, len(order)+1)
+	for _, v := range order {
+		name, ok := remaining[strings.ToLower(v)]
+		if !ok {
+			return nil, fmt.Errorf("the Node Pool %q specified in `upgrade_orchestration.0.node_pool_order` was not found within %s", v, id)
+		}
+		names = append(names, name)
+		delete(remaining, strings.ToLower(v))
+	}
+
+	if name, ok := remaining[strings.ToLower(defaultNodePoolName)]; ok && defaultNodePoolName != "" {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// kubernetesClusterDefaultNodePoolVersionIsConfigured returns whether `orchestrator_version` is specified for the
+// Default Node Pool in the config, rather than being computed
+func kubernetesClusterDefaultNodePoolVersionIsConfigured(d *pluginsdk.ResourceData) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	defaultNodePools := config.GetAttr("default_node_pool")
+	if defaultNodePools.IsNull() || !defaultNodePools.IsKnown() || defaultNodePools.LengthInt() == 0 {
+		return false
+	}
+
+	version := defaultNodePools.Index(cty.NumberIntVal(0)).GetAttr("orchestrator_version")
+	return !version.IsNull()
+}
+
+// kubernetesClusterIsRunningVersion returns whether the Control Plane or Node Pool is running the specified version of
+// Kubernetes, which can either be a full version (e.g. `1.22.6`) or an alias for a minor version (e.g. `1.22`)
+func kubernetesClusterIsRunningVersion(version, currentVersion *string, desiredVersion string) bool {
+	if currentVersion != nil && (*currentVersion == desiredVersion || strings.HasPrefix(*currentVersion, desiredVersion+".")) {
+		return true
+	}
+
+	return currentVersion == nil && version != nil && *version == desiredVersion
+}
+
+func waitForKubernetesClusterToFinishProvisioning(ctx context.Context, containersClient *client.Client, id parse.ClusterId) (*containerservice.ManagedCluster, error) {
+	clusterClient := containersClient.KubernetesClustersClient
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, fmt.Errorf("context is missing a timeout")
+	}
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"InProgress"},
+		Target:  []string{"Finished"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := clusterClient.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+			if err != nil {
+				return nil, "Error", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.ManagedClusterProperties == nil {
+				return nil, "Error", fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			if kubernetesClusterIsProvisioning(resp.ManagedClusterProperties.ProvisioningState) {
+				log.Printf("[INFO] Waiting for the in-progress operation on %s to finish (Provisioning State %q)..", id, *resp.ManagedClusterProperties.ProvisioningState)
+				return resp, "InProgress", nil
+			}
+			return resp, "Finished", nil
+		},
+		PollInterval: 30 * time.Second,
+		Timeout:      time.Until(deadline),
+	}
+	resp, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for the in-progress operation on %s to finish: %+v", id, err)
+	}
+
+	cluster := resp.(containerservice.ManagedCluster)
+	return &cluster, nil
+}
+
+func waitForKubernetesClusterNodePoolToFinishProvisioning(ctx context.Context, containersClient *client.Client, id parse.NodePoolId) (containerservice.AgentPool, error) {
+	nodePoolsClient := containersClient.AgentPoolsClient
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return containerservice.AgentPool{}, fmt.Errorf("context is missing a timeout")
+	}
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"InProgress"},
+		Target:  []string{"Finished"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := nodePoolsClient.Get(ctx, id.ResourceGroup, id.ManagedClusterName, id.AgentPoolName)
+			if err != nil {
+				return nil, "Error", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if props := resp.ManagedClusterAgentPoolProfileProperties; props != nil && kubernetesClusterIsProvisioning(props.ProvisioningState) {
+				log.Printf("[INFO] Waiting for the in-progress operation on %s to finish (Provisioning State %q)..", id, *props.ProvisioningState)
+				return resp, "InProgress", nil
+			}
+			return resp, "Finished", nil
+		},
+		PollInterval: 30 * time.Second,
+		Timeout:      time.Until(deadline),
+	}
+	resp, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return containerservice.AgentPool{}, fmt.Errorf("waiting for the in-progress operation on %s to finish: %+v", id, err)
+	}
+
+	return resp.(containerservice.AgentPool), nil
+}
+
+// kubernetesClusterIsProvisioning returns whether an operation (e.g. an upgrade started by a previous apply which
+// timed out) is in progress
+func kubernetesClusterIsProvisioning(provisioningState *string) bool {
+	if provisioningState == nil {
+		return false
+	}
+
+	switch strings.ToLower(*provisioningState) {
+	case "succeeded", "failed", "canceled", "cancelled":
+		return false
+	}
+	return true
+}
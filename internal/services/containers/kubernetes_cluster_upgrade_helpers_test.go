@@ -0,0 +1,198 @@
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestSortKubernetesClusterNodePoolsForUpgrade(t *testing.T) {
+	id := parse.NewClusterID("00000000-0000-0000-0000-000000000000", "example", "cluster1")
+	testData := []struct {
+		name        string
+		nodePools   []string
+		order       []string
+		defaultPool string
+		expected    []string
+		error       bool
+	}{
+		{
+			name:        "no order",
+			nodePools:   []string{"internal", "default", "batch"},
+			defaultPool: "default",
+			expected:    []string{"default"},
+		},
+		{
+			// Node Pools which aren't ordered are managed by `azurerm_kubernetes_cluster_node_pool` and aren't upgraded
+			name:        "ordered node pools then the default node pool",
+			nodePools:   []string{"internal", "default", "batch"},
+			order:       []string{"internal"},
+			defaultPool: "default",
+			expected:    []string{"internal", "default"},
+		},
+		{
+			name:        "default node pool ordered",
+			nodePools:   []string{"internal", "default", "batch"},
+			order:       []string{"default", "internal"},
+			defaultPool: "default",
+			expected:    []string{"default", "internal"},
+		},
+		{
+			name:      "default node pool excluded",
+			nodePools: []string{"internal", "default", "batch"},
+			order:     []string{"internal"},
+			expected:  []string{"internal"},
+		},
+		{
+			name:        "order is case-insensitive",
+			nodePools:   []string{"Internal", "default"},
+			order:       []string{"internal"},
+			defaultPool: "Default",
+			expected:    []string{"Internal", "default"},
+		},
+		{
+			name:        "unknown node pool",
+			nodePools:   []string{"default"},
+			order:       []string{"missing"},
+			defaultPool: "default",
+			error:       true,
+		},
+		{
+			name:        "node pool ordered twice",
+			nodePools:   []string{"default", "internal"},
+			order:       []string{"internal", "internal"},
+			defaultPool: "default",
+			error:       true,
+		},
+		{
+			name:     "no node pools",
+			expected: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := sortKubernetesClusterNodePoolsForUpgrade(id, v.nodePools, v.order, v.defaultPool)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %+v", actual)
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestKubernetesClusterNodePoolMaxSurgeMatches(t *testing.T) {
+	testData := []struct {
+		name     string
+		expected *containerservice.AgentPoolUpgradeSettings
+		actual   *containerservice.AgentPoolUpgradeSettings
+		matches  bool
+	}{
+		{
+			name:    "unset",
+			matches: true,
+		},
+		{
+			name:     "unset and empty",
+			expected: &containerservice.AgentPoolUpgradeSettings{},
+			actual:   &containerservice.AgentPoolUpgradeSettings{MaxSurge: utils.String("")},
+			matches:  true,
+		},
+		{
+			name:     "restored",
+			expected: &containerservice.AgentPoolUpgradeSettings{MaxSurge: utils.String("2")},
+			actual:   &containerservice.AgentPoolUpgradeSettings{MaxSurge: utils.String("2")},
+			matches:  true,
+		},
+		{
+			// the API may retain the `max_surge` used during the upgrade when it's omitted
+			name:     "not reverted",
+			expected: &containerservice.AgentPoolUpgradeSettings{},
+			actual:   &containerservice.AgentPoolUpgradeSettings{MaxSurge: utils.String("33%")},
+			matches:  false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := kubernetesClusterNodePoolMaxSurgeMatches(v.expected, v.actual); actual != v.matches {
+			t.Fatalf("expected %t but got %t", v.matches, actual)
+		}
+	}
+}
+
+func TestKubernetesClusterIsRunningVersion(t *testing.T) {
+	testData := []struct {
+		name           string
+		version        *string
+		currentVersion *string
+		desiredVersion string
+		expected       bool
+	}{
+		{
+			name:           "current version matches",
+			version:        utils.String("1.22.6"),
+			currentVersion: utils.String("1.22.6"),
+			desiredVersion: "1.22.6",
+			expected:       true,
+		},
+		{
+			name:           "minor version alias matches the current version",
+			version:        utils.String("1.22"),
+			currentVersion: utils.String("1.22.6"),
+			desiredVersion: "1.22",
+			expected:       true,
+		},
+		{
+			name:           "minor version alias is a prefix of a different minor version",
+			currentVersion: utils.String("1.2.6"),
+			desiredVersion: "1.22",
+			expected:       false,
+		},
+		{
+			name:           "current version takes precedence over the requested version",
+			version:        utils.String("1.22.6"),
+			currentVersion: utils.String("1.21.9"),
+			desiredVersion: "1.22.6",
+			expected:       false,
+		},
+		{
+			name:           "requested version is used when the current version isn't returned",
+			version:        utils.String("1.22.6"),
+			desiredVersion: "1.22.6",
+			expected:       true,
+		},
+		{
+			name:           "requested version differs when the current version isn't returned",
+			version:        utils.String("1.21.9"),
+			desiredVersion: "1.22.6",
+			expected:       false,
+		},
+		{
+			name:           "neither version is returned",
+			desiredVersion: "1.22.6",
+			expected:       false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := kubernetesClusterIsRunningVersion(v.version, v.currentVersion, v.desiredVersion); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -242,6 +242,67 @@ func TestAccKubernetesCluster_upgradeSettings(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCluster_upgradeOrchestration(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+	nodePoolName := "azurerm_kubernetes_cluster_node_pool.test"
+	pinnedNodePoolName := "azurerm_kubernetes_cluster_node_pool.pinned"
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradeOrchestrationConfig(data, olderKubernetesVersion),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("kubernetes_version").HasValue(olderKubernetesVersion),
+				check.That(data.ResourceName).Key("default_node_pool.0.orchestrator_version").HasValue(olderKubernetesVersion),
+				acceptance.TestCheckResourceAttr(nodePoolName, "orchestrator_version", olderKubernetesVersion),
+				acceptance.TestCheckResourceAttr(pinnedNodePoolName, "orchestrator_version", olderKubernetesVersion),
+			),
+		},
+		data.ImportStep("upgrade_orchestration"),
+		{
+			// the control plane, default node pool and the ordered node pool should be upgraded - but not the pinned node pool
+			Config: r.upgradeOrchestrationConfig(data, currentKubernetesVersion),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("kubernetes_version").HasValue(currentKubernetesVersion),
+				check.That(data.ResourceName).Key("default_node_pool.0.orchestrator_version").HasValue(currentKubernetesVersion),
+				check.That(data.ResourceName).Key("default_node_pool.0.upgrade_settings.#").HasValue("0"),
+			),
+		},
+		data.ImportStep("upgrade_orchestration"),
+		{
+			// refresh the node pools - the ordered node pool was upgraded by the cluster, whereas the pinned node pool wasn't
+			Config: r.upgradeOrchestrationConfig(data, currentKubernetesVersion),
+			Check: acceptance.ComposeTestCheckFunc(
+				acceptance.TestCheckResourceAttr(nodePoolName, "orchestrator_version", currentKubernetesVersion),
+				acceptance.TestCheckResourceAttr(pinnedNodePoolName, "orchestrator_version", olderKubernetesVersion),
+			),
+		},
+	})
+}
+
+func TestAccKubernetesCluster_upgradeControlPlaneUnsupportedVersionFails(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradeControlPlaneConfig(data, currentKubernetesVersion),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			// downgrading isn't a supported upgrade path
+			Config:      r.upgradeControlPlaneConfig(data, olderKubernetesVersion),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("the Kubernetes Cluster cannot be upgraded from version"),
+		},
+	})
+}
+
 func (KubernetesClusterResource) upgradeControlPlaneConfig(data acceptance.TestData, controlPlaneVersion string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -351,3 +412,54 @@ resource "azurerm_kubernetes_cluster" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, controlPlaneVersion, minCount, maxCount)
 }
+
+func (KubernetesClusterResource) upgradeOrchestrationConfig(data acceptance.TestData, controlPlaneVersion string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%d"
+  kubernetes_version  = %q
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  upgrade_orchestration {
+    node_pool_order = ["internal"]
+    max_surge       = "33%%"
+  }
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 1
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "pinned" {
+  name                  = "pinned"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 1
+  orchestrator_version  = %q
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, controlPlaneVersion, olderKubernetesVersion)
+}
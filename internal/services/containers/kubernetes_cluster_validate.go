@@ -3,10 +3,13 @@ package containers
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -299,3 +302,62 @@ func validateNodePoolSupportsVersion(ctx context.Context, client *client.Client,
 
 	return nil
 }
+
+// validateKubernetesClusterWorkloadIdentity ensures that the OIDC Issuer is enabled when Workload Identity is
+// enabled, since Workload Identity federates credentials using the OIDC Issuer - this is checked at plan time
+// so that enabling (or disabling) either in-place fails before the Cluster is updated
+func validateKubernetesClusterWorkloadIdentity(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("workload_identity_enabled") || !diff.NewValueKnown("oidc_issuer_enabled") {
+		return nil
+	}
+
+	if diff.Get("workload_identity_enabled").(bool) && !diff.Get("oidc_issuer_enabled").(bool) {
+		return fmt.Errorf("`oidc_issuer_enabled` must be set to `true` to enable `workload_identity_enabled`")
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterUpgradePath ensures that the Control Plane can be upgraded from the current version of
+// Kubernetes to the desired version (as returned by the `azurerm_kubernetes_service_versions` Data Source), so
+// that an unsupported upgrade (for example skipping a minor version) fails at plan time rather than partway through
+func validateKubernetesClusterUpgradePath(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("kubernetes_version") || !diff.NewValueKnown("kubernetes_version") {
+		return nil
+	}
+
+	old, new := diff.GetChange("kubernetes_version")
+	currentVersion := old.(string)
+	desiredVersion := new.(string)
+	if currentVersion == "" || desiredVersion == "" {
+		return nil
+	}
+
+	location := azure.NormalizeLocation(diff.Get("location").(string))
+	upgrades, err := kubernetesServiceVersionUpgrades(ctx, meta.(*clients.Client).Containers, location, currentVersion)
+	if err != nil {
+		// this is best-effort (for example the credentials may not be able to list the available versions), in which
+		// case the API is left to validate the upgrade
+		log.Printf("[WARN] unable to validate the upgrade from Kubernetes version %q to %q in %q: %+v", currentVersion, desiredVersion, location, err)
+		return nil
+	}
+
+	// the current version may no longer be available (e.g. it's been deprecated) in which case the upgrade paths
+	// are unknown and the API is left to validate the upgrade
+	if upgrades == nil {
+		log.Printf("[DEBUG] Kubernetes version %q is not available in %q - unable to validate the upgrade to %q", currentVersion, location, desiredVersion)
+		return nil
+	}
+
+	for _, v := range *upgrades {
+		if v == desiredVersion || strings.HasPrefix(v, desiredVersion+".") {
+			return nil
+		}
+	}
+
+	availableUpgrades := "(none)"
+	if len(*upgrades) > 0 {
+		availableUpgrades = strings.Join(*upgrades, ", ")
+	}
+	return fmt.Errorf("the Kubernetes Cluster cannot be upgraded from version %q to %q in %q - the versions which are available as an upgrade are: %s", currentVersion, desiredVersion, location, availableUpgrades)
+}
@@ -6,12 +6,13 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-01-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
 	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -216,6 +217,12 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 						Optional: true,
 						ForceNew: true,
 					},
+					"snapshot_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validate.NodePoolSnapshotID,
+					},
 
 					"upgrade_settings": upgradeSettingsSchema(),
 				}
@@ -709,6 +716,12 @@ func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]containerservice.Manag
 		profile.ProximityPlacementGroupID = utils.String(proximityPlacementGroupId)
 	}
 
+	if snapshotId := raw["snapshot_id"].(string); snapshotId != "" {
+		profile.CreationData = &containerservice.CreationData{
+			SourceResourceID: utils.String(snapshotId),
+		}
+	}
+
 	count := raw["node_count"].(int)
 	maxCount := raw["max_count"].(int)
 	minCount := raw["min_count"].(int)
@@ -1051,6 +1064,15 @@ func FlattenDefaultNodePool(input *[]containerservice.ManagedClusterAgentPoolPro
 		proximityPlacementGroupId = *agentPool.ProximityPlacementGroupID
 	}
 
+	snapshotId := ""
+	if agentPool.CreationData != nil && agentPool.CreationData.SourceResourceID != nil {
+		id, err := parse.NodePoolSnapshotID(*agentPool.CreationData.SourceResourceID)
+		if err != nil {
+			return nil, err
+		}
+		snapshotId = id.ID()
+	}
+
 	vmSize := ""
 	if agentPool.VMSize != nil {
 		vmSize = *agentPool.VMSize
@@ -1086,6 +1108,7 @@ func FlattenDefaultNodePool(input *[]containerservice.ManagedClusterAgentPoolPro
 		"pod_subnet_id":                podSubnetId,
 		"orchestrator_version":         orchestratorVersion,
 		"proximity_placement_group_id": proximityPlacementGroupId,
+		"snapshot_id":                  snapshotId,
 		"upgrade_settings":             upgradeSettings,
 		"vnet_subnet_id":               vnetSubnetId,
 		"only_critical_addons_enabled": criticalAddonsEnabled,
@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
@@ -129,3 +131,35 @@ func dataSourceKubernetesServiceVersionsRead(d *pluginsdk.ResourceData, meta int
 
 	return nil
 }
+
+// kubernetesServiceVersionUpgrades returns the versions of Kubernetes which the specified version can be upgraded
+// to in this location - or nil when the specified version isn't available in this location
+func kubernetesServiceVersionUpgrades(ctx context.Context, containersClient *client.Client, location, kubernetesVersion string) (*[]string, error) {
+	listResp, err := containersClient.ServicesClient.ListOrchestrators(ctx, location, "managedClusters")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Kubernetes Versions in %q: %+v", location, err)
+	}
+
+	if props := listResp.OrchestratorVersionProfileProperties; props != nil && props.Orchestrators != nil {
+		for _, orchestrator := range *props.Orchestrators {
+			if orchestrator.OrchestratorType == nil || !strings.EqualFold(*orchestrator.OrchestratorType, "Kubernetes") {
+				continue
+			}
+			if orchestrator.OrchestratorVersion == nil || *orchestrator.OrchestratorVersion != kubernetesVersion {
+				continue
+			}
+
+			upgrades := make([]string, 0)
+			if orchestrator.Upgrades != nil {
+				for _, upgrade := range *orchestrator.Upgrades {
+					if upgrade.OrchestratorVersion != nil {
+						upgrades = append(upgrades, *upgrade.OrchestratorVersion)
+					}
+				}
+			}
+			return &upgrades, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -47,12 +47,15 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 }
 
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		KubernetesClusterNodePoolSnapshotDataSource{},
+	}
 }
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		ContainerRegistryTaskResource{},
 		ContainerConnectedRegistryResource{},
+		KubernetesClusterNodePoolSnapshotResource{},
 	}
 }
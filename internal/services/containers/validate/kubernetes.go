@@ -3,6 +3,9 @@ package validate
 import (
 	"fmt"
 	"regexp"
+	"strings"
+
+	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 )
 
 func KubernetesAdminUserName(i interface{}, k string) (warnings []string, errors []error) {
@@ -37,3 +40,29 @@ func KubernetesDNSPrefix(i interface{}, k string) (warnings []string, errors []e
 
 	return warnings, errors
 }
+
+// KubernetesKeyManagementServiceKeyID validates that the Key Vault Key used for etcd encryption is a versioned
+// Key ID, since the Key Management Service doesn't support versionless Keys
+func KubernetesKeyManagementServiceKeyID(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return warnings, errors
+	}
+
+	id, err := keyVaultParse.ParseOptionallyVersionedNestedItemID(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("parsing %q: %+v", v, err))
+		return warnings, errors
+	}
+
+	if !strings.EqualFold(id.NestedItemType, "keys") {
+		errors = append(errors, fmt.Errorf("expected %q to be the ID of a Key Vault Key but got a Nested Item of type %q", k, id.NestedItemType))
+	}
+
+	if id.Version == "" {
+		errors = append(errors, fmt.Errorf("expected %q to be a versioned Key Vault Key ID but no version was specified in %q", k, v))
+	}
+
+	return warnings, errors
+}
@@ -133,3 +133,39 @@ func TestKubernetesDNSPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestKubernetesKeyManagementServiceKeyID(t *testing.T) {
+	cases := []struct {
+		KeyID  string
+		Errors int
+	}{
+		{
+			KeyID:  "",
+			Errors: 1,
+		},
+		{
+			// versionless
+			KeyID:  "https://example.vault.azure.net/keys/etcd",
+			Errors: 1,
+		},
+		{
+			// not a key
+			KeyID:  "https://example.vault.azure.net/secrets/etcd/fdf067c93bbb4b22bff4d8b7a9a56217",
+			Errors: 1,
+		},
+		{
+			KeyID:  "https://example.vault.azure.net/keys/etcd/fdf067c93bbb4b22bff4d8b7a9a56217",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.KeyID, func(t *testing.T) {
+			_, errors := KubernetesKeyManagementServiceKeyID(tc.KeyID, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected KubernetesKeyManagementServiceKeyID to return %d error(s) not %d: %+v", tc.Errors, len(errors), errors)
+			}
+		})
+	}
+}
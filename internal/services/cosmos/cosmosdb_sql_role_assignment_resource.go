@@ -100,7 +100,9 @@ func resourceCosmosDbSQLRoleAssignmentCreate(d *pluginsdk.ResourceData, meta int
 
 	id := parse.NewSqlRoleAssignmentID(subscriptionId, resourceGroup, accountName, name)
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	existing, err := client.GetSQLRoleAssignment(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
@@ -178,7 +180,9 @@ func resourceCosmosDbSQLRoleAssignmentUpdate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	parameters := documentdb.SQLRoleAssignmentCreateUpdateParameters{
@@ -212,7 +216,9 @@ func resourceCosmosDbSQLRoleAssignmentDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	future, err := client.DeleteSQLRoleAssignment(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
@@ -98,7 +98,9 @@ func databricksWorkspaceCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceData
 
 	// Not sure if I should also lock the key vault here too
 	// or at the very least the key?
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByName(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 	var encryptionEnabled bool
 
@@ -236,7 +238,9 @@ func databricksWorkspaceCustomerManagedKeyDelete(d *pluginsdk.ResourceData, meta
 	workspaceID := workspaces.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.CustomerMangagedKeyName)
 
 	// Not sure if I should also lock the key vault here too
-	locks.ByName(workspaceID.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByName(ctx, workspaceID.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(workspaceID.WorkspaceName, "azurerm_databricks_workspace")
 
 	workspace, err := client.Get(ctx, workspaceID)
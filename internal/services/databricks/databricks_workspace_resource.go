@@ -353,10 +353,14 @@ func resourceDatabricksWorkspaceCreateUpdate(d *pluginsdk.ResourceData, meta int
 		backendPoolName = backendPoolId.BackendAddressPoolName
 		loadBalancerId = lbId.ID()
 
-		locks.ByID(backendPoolId.ID())
+		if err := locks.ByID(ctx, backendPoolId.ID()); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByID(backendPoolId.ID())
 
-		locks.ByID(lbId.ID())
+		if err := locks.ByID(ctx, lbId.ID()); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByID(lbId.ID())
 
 		// check to make sure the load balancer exists as referred to by the Backend Address Pool...
@@ -113,12 +113,14 @@ func resourceVirtualDesktopApplicationGroupCreateUpdate(d *pluginsdk.ResourceDat
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(name, applicationGroupType)
-	defer locks.UnlockByName(name, applicationGroupType)
-
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
+	if err := locks.ByName(ctx, name, applicationGroupType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByName(name, applicationGroupType)
+
 	resourceId := parse.NewApplicationGroupID(subscriptionId, resourceGroup, name).ID()
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, resourceGroup, name)
@@ -245,11 +247,14 @@ func resourceVirtualDesktopApplicationGroupDelete(d *pluginsdk.ResourceData, met
 		return err
 	}
 
-	locks.ByName(id.Name, applicationGroupType)
-	defer locks.UnlockByName(id.Name, applicationGroupType)
-
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
+
+	if err := locks.ByName(ctx, id.Name, applicationGroupType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByName(id.Name, applicationGroupType)
+
 	if _, err = client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
 		return fmt.Errorf("deleting Virtual Desktop Application Group %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 	}
@@ -123,12 +123,14 @@ func resourceVirtualDesktopApplicationCreateUpdate(d *pluginsdk.ResourceData, me
 	name := d.Get("name").(string)
 	applicationGroup, _ := parse.ApplicationGroupID(d.Get("application_group_id").(string))
 
-	locks.ByName(name, applicationType)
-	defer locks.UnlockByName(name, applicationType)
-
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
+	if err := locks.ByName(ctx, name, applicationType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByName(name, applicationType)
+
 	resourceId := parse.NewApplicationID(subscriptionId, applicationGroup.ResourceGroup, applicationGroup.Name, name).ID()
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, applicationGroup.ResourceGroup, applicationGroup.Name, name)
@@ -216,11 +218,14 @@ func resourceVirtualDesktopApplicationDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.Name, applicationType)
-	defer locks.UnlockByName(id.Name, applicationType)
-
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
+
+	if err := locks.ByName(ctx, id.Name, applicationType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByName(id.Name, applicationType)
+
 	if _, err = client.Delete(ctx, id.ResourceGroup, id.ApplicationGroupName, id.Name); err != nil {
 		return fmt.Errorf("deleting Virtual Desktop Application %q (Application Group %q) (Resource Group %q): %+v", id.Name, id.ApplicationGroupName, id.ResourceGroup, err)
 	}
@@ -82,7 +82,9 @@ func resourceVirtualDesktopHostPoolRegistrationInfoCreateUpdate(d *pluginsdk.Res
 		return err
 	}
 
-	locks.ByName(hostpoolId.Name, hostpoolResourceType)
+	if err := locks.ByName(ctx, hostpoolId.Name, hostpoolResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(hostpoolId.Name, hostpoolResourceType)
 
 	// This is a virtual resource so the last segment is hardcoded
@@ -161,7 +163,9 @@ func resourceVirtualDesktopHostPoolRegistrationInfoDelete(d *pluginsdk.ResourceD
 
 	hostpoolId := parse.NewHostPoolID(id.SubscriptionId, id.ResourceGroup, id.HostPoolName)
 
-	locks.ByName(hostpoolId.Name, hostpoolResourceType)
+	if err := locks.ByName(ctx, hostpoolId.Name, hostpoolResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(hostpoolId.Name, hostpoolResourceType)
 
 	resp, err := client.Get(ctx, id.ResourceGroup, id.HostPoolName)
@@ -196,7 +196,9 @@ func resourceVirtualDesktopHostPoolUpdate(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.Name, hostpoolResourceType)
+	if err := locks.ByName(ctx, id.Name, hostpoolResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, hostpoolResourceType)
 
 	update := &desktopvirtualization.HostPoolPatch{}
@@ -297,7 +299,9 @@ func resourceVirtualDesktopHostPoolDelete(d *pluginsdk.ResourceData, meta interf
 
 	id, err := parse.HostPoolID(d.Id())
 
-	locks.ByName(id.Name, hostpoolResourceType)
+	if err := locks.ByName(ctx, id.Name, hostpoolResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, hostpoolResourceType)
 
 	if err != nil {
@@ -75,10 +75,14 @@ func resourceVirtualDesktopWorkspaceApplicationGroupAssociationCreate(d *plugins
 	}
 	associationId := parse.NewWorkspaceApplicationGroupAssociationId(*workspaceId, *applicationGroupId).ID()
 
-	locks.ByName(workspaceId.Name, workspaceResourceType)
+	if err := locks.ByName(ctx, workspaceId.Name, workspaceResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(workspaceId.Name, workspaceResourceType)
 
-	locks.ByName(applicationGroupId.Name, applicationGroupType)
+	if err := locks.ByName(ctx, applicationGroupId.Name, applicationGroupType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(applicationGroupId.Name, applicationGroupType)
 
 	workspace, err := client.Get(ctx, workspaceId.ResourceGroup, workspaceId.Name)
@@ -156,10 +160,14 @@ func resourceVirtualDesktopWorkspaceApplicationGroupAssociationDelete(d *plugins
 		return err
 	}
 
-	locks.ByName(id.Workspace.Name, workspaceResourceType)
+	if err := locks.ByName(ctx, id.Workspace.Name, workspaceResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Workspace.Name, workspaceResourceType)
 
-	locks.ByName(id.ApplicationGroup.Name, applicationGroupType)
+	if err := locks.ByName(ctx, id.ApplicationGroup.Name, applicationGroupType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ApplicationGroup.Name, applicationGroupType)
 
 	workspace, err := client.Get(ctx, id.Workspace.ResourceGroup, id.Workspace.Name)
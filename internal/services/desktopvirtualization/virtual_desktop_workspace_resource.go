@@ -164,11 +164,14 @@ func resourceArmDesktopVirtualizationWorkspaceDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.Name, workspaceResourceType)
-	defer locks.UnlockByName(id.Name, workspaceResourceType)
-
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
+
+	if err := locks.ByName(ctx, id.Name, workspaceResourceType); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByName(id.Name, workspaceResourceType)
+
 	if _, err = client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
 		return fmt.Errorf("deleting Desktop Virtualization Workspace %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 	}
@@ -92,7 +92,9 @@ func (d DiskPoolIscsiTargetLunModel) Create() sdk.ResourceFunc {
 			}
 			id := iscsitargets.NewDiskPoolIscsiTargetLunId(*iscsiTargetId, attachmentId.ManagedDiskId)
 
-			locks.ByID(iscsiTargetId.ID())
+			if err := locks.ByID(ctx, iscsiTargetId.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(iscsiTargetId.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
@@ -208,7 +210,9 @@ func (d DiskPoolIscsiTargetLunModel) Delete() sdk.ResourceFunc {
 			}
 			id := iscsitargets.NewDiskPoolIscsiTargetLunId(*iscsiTargetId, attachmentId.ManagedDiskId)
 
-			locks.ByID(iscsiTargetId.ID())
+			if err := locks.ByID(ctx, iscsiTargetId.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(iscsiTargetId.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
@@ -131,7 +131,9 @@ func (r DisksPoolIscsiTargetLunResource) Destroy(ctx context.Context, clients *c
 
 	iscsiTargetId := id.IscsiTargetId
 
-	locks.ByID(iscsiTargetId.ID())
+	if err := locks.ByID(ctx, iscsiTargetId.ID()); err != nil {
+		return nil, fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(iscsiTargetId.ID())
 
 	client := clients.Disks.DisksPoolIscsiTargetClient
@@ -89,7 +89,7 @@ func (d DisksPoolIscsiTargetResource) Attributes() map[string]*schema.Schema {
 }
 
 func (d DisksPoolIscsiTargetResource) ModelObject() interface{} {
-	return &DisksPoolIscsiTargetResource{}
+	return &DiskPoolIscsiTargetModel{}
 }
 
 func (d DisksPoolIscsiTargetResource) ResourceType() string {
@@ -116,7 +116,9 @@ func (d DisksPoolIscsiTargetResource) Create() sdk.ResourceFunc {
 
 			id := iscsitargets.NewIscsiTargetID(poolId.SubscriptionId, poolId.ResourceGroupName, poolId.DiskPoolName, m.Name)
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
-			locks.ByID(poolId.ID())
+			if err := locks.ByID(ctx, poolId.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(poolId.ID())
 
 			existing, err := client.Get(ctx, id)
@@ -196,7 +198,9 @@ func (d DisksPoolIscsiTargetResource) Delete() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(id.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
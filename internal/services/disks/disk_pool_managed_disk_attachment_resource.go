@@ -75,7 +75,9 @@ func (d DiskPoolManagedDiskAttachmentResource) Create() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(attachment.DiskPoolId)
+			if err := locks.ByID(ctx, attachment.DiskPoolId); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(attachment.DiskPoolId)
 			id := diskpools.NewDiskPoolManagedDiskAttachmentId(*poolId, *diskId)
 
@@ -168,7 +170,9 @@ func (d DiskPoolManagedDiskAttachmentResource) Delete() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(diskToDetach.DiskPoolId)
+			if err := locks.ByID(ctx, diskToDetach.DiskPoolId); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(diskToDetach.DiskPoolId)
 
 			client := metadata.Client.Disks.DiskPoolsClient
@@ -175,7 +175,9 @@ func (r DiskPoolResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(id.ID())
 
 			future, err := client.Delete(ctx, *id)
@@ -205,7 +207,9 @@ func (r DiskPoolResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(metadata.ResourceData.Id())
+			if err := locks.ByID(ctx, metadata.ResourceData.Id()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(metadata.ResourceData.Id())
 
 			patch := diskpools.DiskPoolUpdate{}
@@ -88,7 +88,9 @@ func resourceActiveDirectoryDomainServiceReplicaSetCreate(d *pluginsdk.ResourceD
 		return fmt.Errorf("parsing ID for Domain Service Replica Set")
 	}
 
-	locks.ByName(domainServiceId.Name, DomainServiceResourceName)
+	if err := locks.ByName(ctx, domainServiceId.Name, DomainServiceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(domainServiceId.Name, DomainServiceResourceName)
 
 	domainService, err := client.Get(ctx, domainServiceId.ResourceGroup, domainServiceId.Name)
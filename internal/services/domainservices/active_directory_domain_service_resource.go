@@ -283,7 +283,9 @@ func resourceActiveDirectoryDomainServiceCreateUpdate(d *pluginsdk.ResourceData,
 	resourceGroup := d.Get("resource_group_name").(string)
 	resourceErrorName := fmt.Sprintf("Domain Service (Name: %q, Resource Group: %q)", name, resourceGroup)
 
-	locks.ByName(name, DomainServiceResourceName)
+	if err := locks.ByName(ctx, name, DomainServiceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(name, DomainServiceResourceName)
 
 	// If this is a new resource, we cannot determine the resource ID until after it has been created since we need to
@@ -88,10 +88,14 @@ func resourceEventHubAuthorizationRuleCreateUpdate(d *pluginsdk.ResourceData, me
 		}
 	}
 
-	locks.ByName(id.EventHubName, eventHubResourceName)
+	if err := locks.ByName(ctx, id.EventHubName, eventHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.EventHubName, eventHubResourceName)
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	parameters := authorizationruleseventhubs.AuthorizationRule{
@@ -187,10 +191,14 @@ func resourceEventHubAuthorizationRuleDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.EventHubName, eventHubResourceName)
+	if err := locks.ByName(ctx, id.EventHubName, eventHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.EventHubName, eventHubResourceName)
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	if resp, err := eventhubClient.DeleteAuthorizationRule(ctx, *id); err != nil {
@@ -147,7 +147,9 @@ func resourceFirewallApplicationRuleCollectionCreateUpdate(d *pluginsdk.Resource
 		return fmt.Errorf("expanding Firewall Application Rules: %+v", err)
 	}
 
-	locks.ByName(firewallName, azureFirewallResourceName)
+	if err := locks.ByName(ctx, firewallName, azureFirewallResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(firewallName, azureFirewallResourceName)
 
 	firewall, err := client.Get(ctx, resourceGroup, firewallName)
@@ -324,7 +326,9 @@ func resourceFirewallApplicationRuleCollectionDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.AzureFirewallName, azureFirewallResourceName)
+	if err := locks.ByName(ctx, id.AzureFirewallName, azureFirewallResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, azureFirewallResourceName)
 
 	firewall, err := client.Get(ctx, id.ResourceGroup, id.AzureFirewallName)
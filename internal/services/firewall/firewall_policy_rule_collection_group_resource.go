@@ -439,7 +439,9 @@ func resourceFirewallPolicyRuleCollectionGroupCreateUpdate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(policyId.Name, azureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, policyId.Name, azureFirewallPolicyResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(policyId.Name, azureFirewallPolicyResourceName)
 
 	param := network.FirewallPolicyRuleCollectionGroup{
@@ -537,7 +539,9 @@ func resourceFirewallPolicyRuleCollectionGroupDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.FirewallPolicyName, azureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, id.FirewallPolicyName, azureFirewallPolicyResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.FirewallPolicyName, azureFirewallPolicyResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.FirewallPolicyName, id.RuleCollectionGroupName)
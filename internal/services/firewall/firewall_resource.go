@@ -330,13 +330,19 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.ByName(id.AzureFirewallName, azureFirewallResourceName)
+	if err := locks.ByName(ctx, id.AzureFirewallName, azureFirewallResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, azureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, subnetToLock, SubnetResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
 
 	if !d.IsNewResource() {
@@ -500,13 +506,19 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.ByName(id.AzureFirewallName, azureFirewallResourceName)
+	if err := locks.ByName(ctx, id.AzureFirewallName, azureFirewallResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, azureFirewallResourceName)
 
-	locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNamesToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, &subnetNamesToLock, SubnetResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
 
 	// Change this back to using the SDK method once https://github.com/Azure/azure-sdk-for-go/issues/17013 is addressed.
@@ -130,7 +130,9 @@ func resourceHPCCacheAccessPolicyCreateUpdate(d *pluginsdk.ResourceData, meta in
 	}
 	id := parse.NewCacheAccessPolicyID(cacheId.SubscriptionId, cacheId.ResourceGroup, cacheId.Name, name)
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existCache, err := client.Get(ctx, id.ResourceGroup, id.CacheName)
@@ -252,7 +254,9 @@ func resourceHPCCacheAccessPolicyDelete(d *pluginsdk.ResourceData, meta interfac
 	}
 	cacheId := parse.NewCacheID(id.SubscriptionId, id.ResourceGroup, id.CacheName)
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existCache, err := client.Get(ctx, id.ResourceGroup, id.CacheName)
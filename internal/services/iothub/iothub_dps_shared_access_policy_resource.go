@@ -120,7 +120,9 @@ func resourceIotHubDPSSharedAccessPolicyCreateUpdate(d *pluginsdk.ResourceData,
 
 	iothubDpsId := parse.NewIotHubDpsID(subscriptionId, d.Get("resource_group_name").(string), d.Get("iothub_dps_name").(string))
 
-	locks.ByName(iothubDpsId.ProvisioningServiceName, IothubResourceName)
+	if err := locks.ByName(ctx, iothubDpsId.ProvisioningServiceName, IothubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(iothubDpsId.ProvisioningServiceName, IothubResourceName)
 
 	iothubDps, err := client.Get(ctx, iothubDpsId.ProvisioningServiceName, iothubDpsId.ResourceGroup)
@@ -262,7 +264,9 @@ func resourceIotHubDPSSharedAccessPolicyDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(id.ProvisioningServiceName, IothubResourceName)
+	if err := locks.ByName(ctx, id.ProvisioningServiceName, IothubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ProvisioningServiceName, IothubResourceName)
 
 	iothubDps, err := client.Get(ctx, id.ProvisioningServiceName, id.ResourceGroup)
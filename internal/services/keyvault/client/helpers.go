@@ -12,6 +12,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
+// keyVaultsCache maps the name of a Key Vault to its Resource ID and Data Plane URI - which is used to find the
+// Key Vault for a nested item (e.g. a Secret) whose ID is a Data Plane URI. This isn't a response cache (and as
+// such isn't part of `common.ReadCache`, which is opt-in): Key Vaults are added when they're created/read since
+// the Resources API used to look them up by name is eventually consistent, and may not yet return a new Key Vault.
 var (
 	keyVaultsCache = map[string]keyVaultDetails{}
 	keysmith       = &sync.RWMutex{}
@@ -118,7 +118,9 @@ func resourceKeyVaultAccessPolicyCreateOrDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	// Locking to prevent parallel changes causing issues
-	locks.ByName(vaultId.Name, keyVaultResourceName)
+	if err := locks.ByName(ctx, vaultId.Name, keyVaultResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(vaultId.Name, keyVaultResourceName)
 
 	if d.IsNewResource() {
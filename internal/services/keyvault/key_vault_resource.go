@@ -243,7 +243,9 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.Name, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.Name, keyVaultResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
 	// check for the presence of an existing, live one which should be imported into the state
@@ -341,7 +343,9 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
@@ -409,7 +413,9 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.Name, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.Name, keyVaultResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
 	d.Partial(true)
@@ -488,7 +494,9 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 			}
 		}
 
-		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+			return fmt.Errorf("acquiring locks: %+v", err)
+		}
 		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 		update.Properties.NetworkAcls = networkAcls
@@ -686,7 +694,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.Name, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.Name, keyVaultResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name)
@@ -738,7 +748,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -87,7 +87,9 @@ func resourceKustoClusterCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceDat
 		return err
 	}
 
-	locks.ByName(clusterID.Name, "azurerm_kusto_cluster")
+	if err := locks.ByName(ctx, clusterID.Name, "azurerm_kusto_cluster"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(clusterID.Name, "azurerm_kusto_cluster")
 
 	cluster, err := clusterClient.Get(ctx, clusterID.ResourceGroup, clusterID.Name)
@@ -248,7 +250,9 @@ func resourceKustoClusterCustomerManagedKeyDelete(d *pluginsdk.ResourceData, met
 		return err
 	}
 
-	locks.ByName(clusterID.Name, "azurerm_kusto_cluster")
+	if err := locks.ByName(ctx, clusterID.Name, "azurerm_kusto_cluster"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(clusterID.Name, "azurerm_kusto_cluster")
 
 	// confirm it still exists prior to trying to update it, else we'll get an error
@@ -262,7 +262,9 @@ func resourceKustoClusterCreateUpdate(d *pluginsdk.ResourceData, meta interface{
 		}
 	}
 
-	locks.ByID(id.Name)
+	if err := locks.ByID(ctx, id.Name); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(id.Name)
 
 	sku, err := expandKustoClusterSku(d.Get("sku").([]interface{}))
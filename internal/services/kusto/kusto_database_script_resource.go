@@ -99,7 +99,9 @@ func resourceKustoDatabaseScriptCreateUpdate(d *pluginsdk.ResourceData, meta int
 	}
 
 	clusterId := parse.NewClusterID(databaseId.SubscriptionId, databaseId.ResourceGroup, databaseId.ClusterName)
-	locks.ByID(clusterId.ID())
+	if err := locks.ByID(ctx, clusterId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(clusterId.ID())
 
 	forceUpdateTag := d.Get("force_an_update_when_value_changed").(string)
@@ -88,7 +88,9 @@ func (r BackendAddressPoolAddressResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(poolId.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByName(ctx, poolId.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByName(poolId.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			// Backend Addresses can not be created for Basic sku, so we have to check
@@ -221,7 +223,9 @@ func (r BackendAddressPoolAddressResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByName(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			pool, err := client.Get(ctx, id.ResourceGroup, id.LoadBalancerName, id.BackendAddressPoolName)
@@ -276,7 +280,9 @@ func (r BackendAddressPoolAddressResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByName(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			var model BackendAddressPoolAddressModel
@@ -375,7 +375,9 @@ func resourceLogicAppWorkflowUpdate(d *pluginsdk.ResourceData, meta interface{})
 	}
 
 	// lock to prevent against Actions, Parameters or Triggers conflicting
-	locks.ByName(id.Name, logicAppResourceName)
+	if err := locks.ByName(ctx, id.Name, logicAppResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, logicAppResourceName)
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name)
@@ -565,7 +567,9 @@ func resourceLogicAppWorkflowDelete(d *pluginsdk.ResourceData, meta interface{})
 	}
 
 	// lock to prevent against Actions, Parameters or Triggers conflicting
-	locks.ByName(id.Name, logicAppResourceName)
+	if err := locks.ByName(ctx, id.Name, logicAppResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, logicAppResourceName)
 
 	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -59,7 +59,9 @@ func resourceLogicAppComponentUpdate(d *pluginsdk.ResourceData, meta interface{}
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %s %s %q", workflowId, kind, name)
 
 	// lock to prevent against Actions or Triggers conflicting
-	locks.ByName(workflowId.Name, logicAppResourceName)
+	if err := locks.ByName(ctx, workflowId.Name, logicAppResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(workflowId.Name, logicAppResourceName)
 
 	read, err := client.Get(ctx, workflowId.ResourceGroup, workflowId.Name)
@@ -137,7 +139,9 @@ func resourceLogicAppComponentRemove(d *pluginsdk.ResourceData, meta interface{}
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %q (Resource Group %q) %s %q Deletion", logicAppName, resourceGroup, kind, name)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(logicAppName, logicAppResourceName)
+	if err := locks.ByName(ctx, logicAppName, logicAppResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(logicAppName, logicAppResourceName)
 
 	read, err := client.Get(ctx, resourceGroup, logicAppName)
@@ -207,7 +211,9 @@ func retreiveLogicAppTriggerCallbackUrl(d *pluginsdk.ResourceData, meta interfac
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %q (Resource Group %q) %s %q", logicAppName, resourceGroup, "trigger", name)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(logicAppName, logicAppResourceName)
+	if err := locks.ByName(ctx, logicAppName, logicAppResourceName); err != nil {
+		return nil, fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(logicAppName, logicAppResourceName)
 
 	result, err := client.TriggersClient.ListCallbackURL(ctx, resourceGroup, logicAppName, name)
@@ -226,7 +232,9 @@ func retrieveLogicAppComponent(d *pluginsdk.ResourceData, meta interface{}, reso
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %q (Resource Group %q) %s %q", logicAppName, resourceGroup, kind, name)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(logicAppName, logicAppResourceName)
+	if err := locks.ByName(ctx, logicAppName, logicAppResourceName); err != nil {
+		return nil, nil, fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(logicAppName, logicAppResourceName)
 
 	read, err := client.Get(ctx, resourceGroup, logicAppName)
@@ -176,7 +176,9 @@ func resourceMsSqlDatabaseCreateUpdate(d *pluginsdk.ResourceData, meta interface
 	// upgrading those SKUs, we'll try to upgrade the partner databases first.
 
 	// Place a lock for the current database so any partner resources can't bump its SKU out of band
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(id.ID())
 
 	if skuName := d.Get("sku_name"); !d.IsNewResource() && d.HasChange("sku_name") && skuName != "" {
@@ -192,7 +194,9 @@ func resourceMsSqlDatabaseCreateUpdate(d *pluginsdk.ResourceData, meta interface
 				return fmt.Errorf("parsing ID for Replication Partner Database %q: %+v", *partnerDatabase.ID, err)
 			}
 
-			locks.ByID(partnerDatabaseId.ID())
+			if err := locks.ByID(ctx, partnerDatabaseId.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(partnerDatabaseId.ID())
 		}
 
@@ -85,7 +85,9 @@ func resourceMySQLServerKeyCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(serverID.Name, mySQLServerResourceName)
+	if err := locks.ByName(ctx, serverID.Name, mySQLServerResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(serverID.Name, mySQLServerResourceName)
 
 	if d.IsNewResource() {
@@ -180,7 +182,9 @@ func resourceMySQLServerKeyDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.ServerName, mySQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, mySQLServerResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ServerName, mySQLServerResourceName)
 
 	future, err := client.Delete(ctx, id.ServerName, id.Name, id.ResourceGroup)
@@ -65,7 +65,9 @@ func resourceNATGatewayPublicIpPrefixAssociationCreate(d *pluginsdk.ResourceData
 		return err
 	}
 
-	locks.ByName(parsedNatGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedNatGatewayId.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedNatGatewayId.Name, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, parsedNatGatewayId.ResourceGroup, parsedNatGatewayId.Name, "")
@@ -176,7 +178,9 @@ func resourceNATGatewayPublicIpPrefixAssociationDelete(d *pluginsdk.ResourceData
 		return err
 	}
 
-	locks.ByName(id.NatGateway.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, id.NatGateway.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NatGateway.Name, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, id.NatGateway.ResourceGroup, id.NatGateway.Name, "")
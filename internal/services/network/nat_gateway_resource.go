@@ -95,7 +95,9 @@ func resourceNatGatewayCreate(d *pluginsdk.ResourceData, meta interface{}) error
 
 	id := parse.NewNatGatewayID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, id.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, natGatewayResourceName)
 
 	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -152,7 +154,9 @@ func resourceNatGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, id.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, natGatewayResourceName)
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -260,7 +264,9 @@ func resourceNatGatewayDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, id.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, natGatewayResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
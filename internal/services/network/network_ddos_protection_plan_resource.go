@@ -93,10 +93,14 @@ func resourceNetworkDDoSProtectionPlanCreateUpdate(d *pluginsdk.ResourceData, me
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkDDoSProtectionPlanResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	parameters := network.DdosProtectionPlan{
@@ -181,10 +185,14 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkDDoSProtectionPlanResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
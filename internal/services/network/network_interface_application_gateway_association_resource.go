@@ -83,7 +83,9 @@ func resourceNetworkInterfaceApplicationGatewayBackendAddressPoolAssociationCrea
 		return err
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.Name, networkInterfaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -246,7 +248,9 @@ func resourceNetworkInterfaceApplicationGatewayBackendAddressPoolAssociationDele
 
 	backendAddressPoolId := splitId[1]
 
-	locks.ByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicID.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, nicID.ResourceGroup, nicID.NetworkInterfaceName, "")
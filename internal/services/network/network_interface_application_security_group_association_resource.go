@@ -78,7 +78,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationCreate(d *plugin
 		return err
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.Name, networkInterfaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -194,7 +196,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationDelete(d *plugin
 
 	applicationSecurityGroupId := splitId[1]
 
-	locks.ByName(nicID.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicID.Name, networkInterfaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(nicID.Name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, nicID.ResourceGroup, nicID.Name, "")
@@ -127,13 +127,19 @@ func resourceNetworkProfileCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkProfileResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkProfileResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, subnetsToLock, SubnetResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
 
 	parameters := network.Profile{
@@ -221,13 +227,19 @@ func resourceNetworkProfileDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkProfileResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkProfileResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, subnetsToLock, SubnetResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
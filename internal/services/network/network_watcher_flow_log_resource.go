@@ -207,7 +207,9 @@ func resourceNetworkWatcherFlowLogCreateUpdate(d *pluginsdk.ResourceData, meta i
 		}
 	}
 
-	locks.ByID(nsgId.ID())
+	if err := locks.ByID(ctx, nsgId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(nsgId.ID())
 
 	loc := d.Get("location").(string)
@@ -328,7 +330,9 @@ func resourceNetworkWatcherFlowLogDelete(d *pluginsdk.ResourceData, meta interfa
 	networkSecurityGroupID := d.Get("network_security_group_id").(string)
 	nsgId, _ := parse.NetworkSecurityGroupID(networkSecurityGroupID)
 
-	locks.ByID(nsgId.ID())
+	if err := locks.ByID(ctx, nsgId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(nsgId.ID())
 
 	id, err := parse.FlowLogID(d.Id())
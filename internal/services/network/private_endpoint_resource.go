@@ -286,7 +286,9 @@ func resourcePrivateEndpointCreate(d *pluginsdk.ResourceData, meta interface{})
 	cosmosDbResIds := getCosmosDbResIdInPrivateServiceConnections(parameters.PrivateEndpointProperties)
 	for _, cosmosDbResId := range cosmosDbResIds {
 		log.Printf("[DEBUG] Add Lock For Private Endpoint %q, lock name: %q", id.Name, cosmosDbResId)
-		locks.ByName(cosmosDbResId, "azurerm_private_endpoint")
+		if err := locks.ByName(ctx, cosmosDbResId, "azurerm_private_endpoint"); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		//goland:noinspection GoDeferInLoop
 		defer locks.UnlockByName(cosmosDbResId, "azurerm_private_endpoint")
 	}
@@ -557,7 +559,9 @@ func resourcePrivateEndpointDelete(d *pluginsdk.ResourceData, meta interface{})
 	}
 	cosmosDbResIds := getCosmosDbResIdInPrivateServiceConnections(parameters.PrivateEndpointProperties)
 	for _, cosmosDbResId := range cosmosDbResIds {
-		locks.ByName(cosmosDbResId, "azurerm_private_endpoint")
+		if err := locks.ByName(ctx, cosmosDbResId, "azurerm_private_endpoint"); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		//goland:noinspection GoDeferInLoop
 		defer locks.UnlockByName(cosmosDbResId, "azurerm_private_endpoint")
 	}
@@ -103,7 +103,9 @@ func resourceRouteCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	route := network.Route{
@@ -173,7 +175,9 @@ func resourceRouteDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.RouteTableName, id.Name)
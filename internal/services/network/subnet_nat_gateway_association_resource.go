@@ -70,11 +70,17 @@ func resourceSubnetNatGatewayAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return fmt.Errorf("parsing NAT gateway id '%s': %+v", natGatewayId, err)
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedGatewayId.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	locks.ByName(parsedSubnetId.Name, SubnetResourceName)
+	if err := locks.ByName(ctx, parsedSubnetId.Name, SubnetResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedSubnetId.Name, SubnetResourceName)
 
 	subnet, err := client.Get(ctx, parsedSubnetId.ResourceGroup, parsedSubnetId.VirtualNetworkName, parsedSubnetId.Name, "")
@@ -205,9 +211,13 @@ func resourceSubnetNatGatewayAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedGatewayId.Name, natGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
 	// ensure we get the latest state
@@ -166,7 +166,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 
 	id := parse.NewHubVirtualNetworkConnectionID(virtualHubId.SubscriptionId, virtualHubId.ResourceGroup, virtualHubId.Name, d.Get("name").(string))
 
-	locks.ByName(virtualHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtualHubId.Name, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(virtualHubId.Name, virtualHubResourceName)
 
 	remoteVirtualNetworkId, err := parse.VirtualNetworkID(d.Get("remote_virtual_network_id").(string))
@@ -174,7 +176,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(remoteVirtualNetworkId.Name, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, remoteVirtualNetworkId.Name, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(remoteVirtualNetworkId.Name, VirtualNetworkResourceName)
 
 	if d.IsNewResource() {
@@ -279,7 +283,9 @@ func resourceVirtualHubConnectionDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.Name)
@@ -135,7 +135,9 @@ func resourceVirtualHubCreateUpdate(d *pluginsdk.ResourceData, meta interface{})
 
 	id := parse.NewVirtualHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.Name, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	if d.IsNewResource() {
@@ -277,7 +279,9 @@ func resourceVirtualHubDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.Name, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -99,7 +99,9 @@ func resourceVirtualHubRouteTableRouteCreateUpdate(d *pluginsdk.ResourceData, me
 		return err
 	}
 
-	locks.ByName(routeTableId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, routeTableId.VirtualHubName, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(routeTableId.VirtualHubName, virtualHubResourceName)
 
 	routeTable, err := client.Get(ctx, routeTableId.ResourceGroup, routeTableId.VirtualHubName, routeTableId.Name)
@@ -219,7 +221,9 @@ func resourceVirtualHubRouteTableRouteDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(route.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, route.VirtualHubName, virtualHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(route.VirtualHubName, virtualHubResourceName)
 
 	// get latest list of routes
@@ -95,12 +95,37 @@ func TestAccVirtualNetworkPeering_update(t *testing.T) {
 	})
 }
 
+func TestAccVirtualNetworkPeering_crossSubscription(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	if data.Subscriptions.Secondary == "" {
+		t.Skipf("The secondary subscription is not specified")
+	}
+	r := VirtualNetworkPeeringResource{}
+	secondResourceName := "azurerm_virtual_network_peering.test2"
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.crossSubscription(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(secondResourceName).ExistsInAzure(r),
+				acceptance.TestCheckResourceAttr(secondResourceName, "subscription_id", data.Subscriptions.Secondary),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t VirtualNetworkPeeringResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.VirtualNetworkPeeringID(state.ID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := clients.Network.VnetPeeringsClient.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
+	subscriptionClient, err := clients.ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := subscriptionClient.Network.VnetPeeringsClient.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s: %+v", *id, err)
 	}
@@ -183,6 +208,62 @@ resource "azurerm_virtual_network_peering" "import" {
 `, r.basic(data))
 }
 
+func (VirtualNetworkPeeringResource) crossSubscription(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+provider "azurerm-alt" {
+  subscription_id = "%[1]s"
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[2]d"
+  location = "%[3]s"
+}
+
+resource "azurerm_virtual_network" "test1" {
+  name                = "acctestvirtnet-1-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  address_space       = ["10.0.1.0/24"]
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_resource_group" "alt" {
+  provider = azurerm-alt
+  name     = "acctestRG-alt-%[2]d"
+  location = "%[3]s"
+}
+
+resource "azurerm_virtual_network" "test2" {
+  provider            = azurerm-alt
+  name                = "acctestvirtnet-2-%[2]d"
+  resource_group_name = azurerm_resource_group.alt.name
+  address_space       = ["10.0.2.0/24"]
+  location            = azurerm_resource_group.alt.location
+}
+
+resource "azurerm_virtual_network_peering" "test1" {
+  name                         = "acctestpeer-1-%[2]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  virtual_network_name         = azurerm_virtual_network.test1.name
+  remote_virtual_network_id    = azurerm_virtual_network.test2.id
+  allow_virtual_network_access = true
+}
+
+resource "azurerm_virtual_network_peering" "test2" {
+  name                         = "acctestpeer-2-%[2]d"
+  resource_group_name          = azurerm_resource_group.alt.name
+  virtual_network_name         = azurerm_virtual_network.test2.name
+  remote_virtual_network_id    = azurerm_virtual_network.test1.id
+  subscription_id              = "%[1]s"
+  allow_virtual_network_access = true
+}
+`, data.Subscriptions.Secondary, data.RandomInteger, data.Locations.Primary)
+}
+
 func (VirtualNetworkPeeringResource) basicUpdate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
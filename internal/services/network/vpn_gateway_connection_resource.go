@@ -374,7 +374,9 @@ func resourceVpnGatewayConnectionResourceCreateUpdate(d *pluginsdk.ResourceData,
 		}
 	}
 
-	locks.ByName(gatewayId.Name, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, gatewayId.Name, VPNGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(gatewayId.Name, VPNGatewayResourceName)
 
 	param := network.VpnConnection{
@@ -490,7 +492,9 @@ func resourceVpnGatewayConnectionResourceDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, id.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.VpnGatewayName, VPNGatewayResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VpnGatewayName, id.Name)
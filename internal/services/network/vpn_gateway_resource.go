@@ -293,7 +293,9 @@ func resourceVPNGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, id.Name, VPNGatewayResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, VPNGatewayResourceName)
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
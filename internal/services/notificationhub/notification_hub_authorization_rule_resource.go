@@ -113,10 +113,14 @@ func resourceNotificationHubAuthorizationRuleCreateUpdate(d *pluginsdk.ResourceD
 		}
 	}
 
-	locks.ByName(id.NotificationHubName, notificationHubResourceName)
+	if err := locks.ByName(ctx, id.NotificationHubName, notificationHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NotificationHubName, notificationHubResourceName)
 
-	locks.ByName(id.NamespaceName, notificationHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, notificationHubNamespaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NamespaceName, notificationHubNamespaceResourceName)
 
 	manage := d.Get("manage").(bool)
@@ -190,10 +194,14 @@ func resourceNotificationHubAuthorizationRuleDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.NotificationHubName, notificationHubResourceName)
+	if err := locks.ByName(ctx, id.NotificationHubName, notificationHubResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NotificationHubName, notificationHubResourceName)
 
-	locks.ByName(id.NamespaceName, notificationHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, notificationHubNamespaceResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.NamespaceName, notificationHubNamespaceResourceName)
 
 	resp, err := client.DeleteAuthorizationRule(ctx, id.ResourceGroup, id.NamespaceName, id.NotificationHubName, id.AuthorizationRuleName)
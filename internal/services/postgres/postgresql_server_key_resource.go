@@ -94,7 +94,9 @@ func resourcePostgreSQLServerKeyCreateUpdate(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("cannot compose name for PostgreSQL Server Key (Resource Group %q / Server %q): %+v", serverId.ResourceGroup, serverId.Name, err)
 	}
 
-	locks.ByName(serverId.Name, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, serverId.Name, postgreSQLServerResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(serverId.Name, postgreSQLServerResourceName)
 
 	if d.IsNewResource() {
@@ -177,7 +179,9 @@ func resourcePostgreSQLServerKeyDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	future, err := client.Delete(ctx, id.ServerName, id.KeyName, id.ResourceGroup)
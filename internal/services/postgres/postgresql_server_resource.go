@@ -594,7 +594,9 @@ func resourcePostgreSQLServerUpdate(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("waiting for %s to become available: %+v", *id, err)
 		}
 	}
-	locks.ByID(primaryID)
+	if err := locks.ByID(ctx, primaryID); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(primaryID)
 
 	sku, err := expandServerSkuName(d.Get("sku_name").(string))
@@ -69,21 +69,48 @@ func resourcePrivateDnsZoneVirtualNetworkLink() *pluginsdk.Resource {
 			// TODO: make this case sensitive once the API's fixed https://github.com/Azure/azure-rest-api-specs/issues/10933
 			"resource_group_name": azure.SchemaResourceGroupNameDiffSuppress(),
 
+			// the Subscription containing the Private DNS Zone, which defaults to the Subscription the Provider is configured for
+			"subscription_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
 			"tags": tags.Schema(),
 		},
 	}
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
+	if v := d.Get("subscription_id").(string); v != "" {
+		subscriptionId = v
+	}
+	if !d.IsNewResource() {
+		// the Private DNS Zone may live in another Subscription (e.g. when imported), in which case it's updated there
+		existingId, err := parse.VirtualNetworkLinkID(d.Id())
+		if err != nil {
+			return err
+		}
+		subscriptionId = existingId.SubscriptionId
+	}
+
 	vNetID := d.Get("virtual_network_id").(string)
 	registrationEnabled := d.Get("registration_enabled").(bool)
 
 	resourceId := parse.NewVirtualNetworkLinkID(subscriptionId, d.Get("resource_group_name").(string), d.Get("private_dns_zone_name").(string), d.Get("name").(string))
+
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(resourceId.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, resourceId.ResourceGroup, resourceId.PrivateDnsZoneName, resourceId.Name)
 		if err != nil {
@@ -128,7 +155,6 @@ func resourcePrivateDnsZoneVirtualNetworkLinkCreateUpdate(d *pluginsdk.ResourceD
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -137,6 +163,12 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *pluginsdk.ResourceData, met
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+
 	resp, err := client.Get(ctx, id.ResourceGroup, id.PrivateDnsZoneName, id.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -149,6 +181,7 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *pluginsdk.ResourceData, met
 	d.Set("name", id.Name)
 	d.Set("private_dns_zone_name", id.PrivateDnsZoneName)
 	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("subscription_id", id.SubscriptionId)
 
 	if props := resp.VirtualNetworkLinkProperties; props != nil {
 		d.Set("registration_enabled", props.RegistrationEnabled)
@@ -162,7 +195,6 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *pluginsdk.ResourceData, met
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -171,6 +203,12 @@ func resourcePrivateDnsZoneVirtualNetworkLinkDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+
 	etag := ""
 	future, err := client.Delete(ctx, id.ResourceGroup, id.PrivateDnsZoneName, id.Name, etag)
 	if err != nil {
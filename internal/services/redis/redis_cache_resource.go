@@ -417,10 +417,14 @@ func resourceRedisCacheCreate(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByName(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.Name, network.SubnetResourceName)
+		if err := locks.ByName(ctx, parsed.Name, network.SubnetResourceName); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByName(parsed.Name, network.SubnetResourceName)
 
 		parameters.SubnetID = utils.String(v.(string))
@@ -699,10 +703,14 @@ func resourceRedisCacheDelete(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByName(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.Name, network.SubnetResourceName)
+		if err := locks.ByName(ctx, parsed.Name, network.SubnetResourceName); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByName(parsed.Name, network.SubnetResourceName)
 	}
 
@@ -65,7 +65,7 @@ type VaultCertificates struct {
 
 type VmSecrets struct {
 	SourceVault  string              `tfschema:"vault_id"`
-	Certificates []VaultCertificates `tfschema:"certificate"`
+	Certificates []VaultCertificates `tfschema:"certificates"`
 }
 
 type NodeType struct {
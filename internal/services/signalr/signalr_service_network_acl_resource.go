@@ -151,7 +151,9 @@ func resourceSignalRServiceNetworkACLCreateUpdate(d *pluginsdk.ResourceData, met
 		return err
 	}
 
-	locks.ByName(id.ResourceName, "azurerm_signalr_service")
+	if err := locks.ByName(ctx, id.ResourceName, "azurerm_signalr_service"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ResourceName, "azurerm_signalr_service")
 
 	resp, err := client.Get(ctx, *id)
@@ -257,7 +259,9 @@ func resourceSignalRServiceNetworkACLDelete(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(id.ResourceName, "azurerm_signalr_service")
+	if err := locks.ByName(ctx, id.ResourceName, "azurerm_signalr_service"); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.ResourceName, "azurerm_signalr_service")
 
 	resp, err := client.Get(ctx, *id)
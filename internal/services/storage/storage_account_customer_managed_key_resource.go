@@ -5,7 +5,7 @@ import (
 	"log"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
@@ -87,7 +87,9 @@ func resourceStorageAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceD
 		return err
 	}
 
-	locks.ByName(storageAccountID.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountID.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(storageAccountID.Name, storageAccountResourceName)
 
 	storageAccount, err := storageClient.GetProperties(ctx, storageAccountID.ResourceGroup, storageAccountID.Name, "")
@@ -266,7 +268,9 @@ func resourceStorageAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(storageAccountID.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountID.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(storageAccountID.Name, storageAccountResourceName)
 
 	// confirm it still exists prior to trying to update it, else we'll get an error
@@ -6,7 +6,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -144,7 +144,9 @@ func resourceStorageAccountNetworkRulesCreateUpdate(d *pluginsdk.ResourceData, m
 		resourceGroup = parsedStorageAccountId.ResourceGroup
 	}
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, resourceGroup, storageAccountName, "")
@@ -244,7 +246,9 @@ func resourceStorageAccountNetworkRulesDelete(d *pluginsdk.ResourceData, meta in
 		return err
 	}
 
-	locks.ByName(parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, parsedStorageAccountNetworkRuleId.ResourceGroup, parsedStorageAccountNetworkRuleId.Name, "")
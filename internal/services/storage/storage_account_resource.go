@@ -12,7 +12,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/edgezones"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"
 	azautorest "github.com/Azure/go-autorest/autorest"
 	autorestAzure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -277,6 +277,18 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"sftp_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"local_user_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			// TODO: document this new field in 3.0
 			"allow_nested_items_to_be_public": {
 				Type:     pluginsdk.TypeBool,
@@ -569,6 +581,36 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				},
 			},
 
+			"immutability_policy": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"allow_protected_append_writes": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"state": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(storage.AccountImmutabilityPolicyStateDisabled),
+								string(storage.AccountImmutabilityPolicyStateUnlocked),
+								string(storage.AccountImmutabilityPolicyStateLocked),
+							}, false),
+						},
+
+						"period_since_creation_in_days": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 146000),
+						},
+					},
+				},
+			},
+
 			"share_properties": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -906,8 +948,18 @@ func resourceStorageAccount() *pluginsdk.Resource {
 						return fmt.Errorf("`large_file_share_enabled` cannot be disabled once it's been enabled")
 					}
 				}
+
+				if d.HasChange("immutability_policy") {
+					if err := validateStorageAccountImmutabilityPolicyChange(d.GetChange("immutability_policy")); err != nil {
+						return err
+					}
+				}
 				return nil
 			}),
+			// version-level immutability can only be enabled when the Storage Account is created, and cannot be disabled
+			pluginsdk.ForceNewIfChange("immutability_policy", func(ctx context.Context, old, new, meta interface{}) bool {
+				return len(old.([]interface{})) != len(new.([]interface{}))
+			}),
 			pluginsdk.ForceNewIfChange("account_replication_type", func(ctx context.Context, old, new, meta interface{}) bool {
 				newAccRep := strings.ToUpper(new.(string))
 
@@ -940,7 +992,9 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	id := parse.NewStorageAccountID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	existing, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, "")
@@ -961,6 +1015,8 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	minimumTLSVersion := d.Get("min_tls_version").(string)
 	isHnsEnabled := d.Get("is_hns_enabled").(bool)
 	nfsV3Enabled := d.Get("nfsv3_enabled").(bool)
+	sftpEnabled := d.Get("sftp_enabled").(bool)
+	localUserEnabled := d.Get("local_user_enabled").(bool)
 	allowBlobPublicAccess := d.Get("allow_nested_items_to_be_public").(bool)
 	allowSharedKeyAccess := d.Get("shared_access_key_enabled").(bool)
 	crossTenantReplication := d.Get("cross_tenant_replication_enabled").(bool)
@@ -982,6 +1038,8 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 			NetworkRuleSet:              expandStorageAccountNetworkRules(d, tenantId),
 			IsHnsEnabled:                &isHnsEnabled,
 			EnableNfsV3:                 &nfsV3Enabled,
+			IsSftpEnabled:               &sftpEnabled,
+			IsLocalUserEnabled:          &localUserEnabled,
 			AllowSharedKeyAccess:        &allowSharedKeyAccess,
 			AllowCrossTenantReplication: &crossTenantReplication,
 		},
@@ -1050,6 +1108,11 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("`nfsv3_enabled` can only be used when `is_hns_enabled` is `true`")
 	}
 
+	// SFTP is only supported for accounts with a hierarchical namespace (https://docs.microsoft.com/azure/storage/blobs/secure-file-transfer-protocol-support)
+	if sftpEnabled && !isHnsEnabled {
+		return fmt.Errorf("`sftp_enabled` can only be used when `is_hns_enabled` is `true`")
+	}
+
 	// AccountTier must be Premium for FileStorage
 	if accountKind == string(storage.KindFileStorage) {
 		if string(parameters.Sku.Tier) == string(storage.SkuNameStandardLRS) {
@@ -1069,6 +1132,15 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		parameters.RoutingPreference = expandArmStorageAccountRouting(v.([]interface{}))
 	}
 
+	if v, ok := d.GetOk("immutability_policy"); ok {
+		// version-level immutability is built on top of blob versioning, which the API enables alongside it
+		if !d.Get("blob_properties.0.versioning_enabled").(bool) {
+			return fmt.Errorf("`immutability_policy` can only be used when `blob_properties.0.versioning_enabled` is `true`")
+		}
+
+		parameters.ImmutableStorageWithVersioning = expandStorageAccountImmutabilityPolicy(v.([]interface{}))
+	}
+
 	// TODO 4.0
 	// look into standardizing this across resources that support CMK and at the very least look at improving the UX
 	// for encryption of blob, file, table and queue
@@ -1267,7 +1339,9 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	accountTier := d.Get("account_tier").(string)
@@ -1317,6 +1391,19 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		opts.AccountPropertiesUpdateParameters.AllowCrossTenantReplication = &crossTenantReplication
 	}
 
+	if d.HasChange("sftp_enabled") {
+		sftpEnabled := d.Get("sftp_enabled").(bool)
+		if sftpEnabled && !d.Get("is_hns_enabled").(bool) {
+			return fmt.Errorf("`sftp_enabled` can only be used when `is_hns_enabled` is `true`")
+		}
+		opts.AccountPropertiesUpdateParameters.IsSftpEnabled = &sftpEnabled
+	}
+
+	if d.HasChange("local_user_enabled") {
+		localUserEnabled := d.Get("local_user_enabled").(bool)
+		opts.AccountPropertiesUpdateParameters.IsLocalUserEnabled = &localUserEnabled
+	}
+
 	if _, err := client.Update(ctx, id.ResourceGroup, id.Name, opts); err != nil {
 		return fmt.Errorf("updating Azure Storage Account AllowSharedKeyAccess %q: %+v", id.Name, err)
 	}
@@ -1519,6 +1606,19 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
+	// adding or removing the `immutability_policy` block forces a new resource, so this only updates an existing policy
+	if d.HasChange("immutability_policy") {
+		opts := storage.AccountUpdateParameters{
+			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+				ImmutableStorageWithVersioning: expandStorageAccountImmutabilityPolicy(d.Get("immutability_policy").([]interface{})),
+			},
+		}
+
+		if _, err := client.Update(ctx, id.ResourceGroup, id.Name, opts); err != nil {
+			return fmt.Errorf("updating `immutability_policy` for %s: %+v", *id, err)
+		}
+	}
+
 	// azure_files_authentication must be the last to be updated, cause it'll occupy the storage account for several minutes after receiving the response 200 OK. Issue: https://github.com/Azure/azure-rest-api-specs/issues/11272
 	if d.HasChange("azure_files_authentication") {
 		// due to service issue: https://github.com/Azure/azure-rest-api-specs/issues/12473, we need to update to None before changing its DirectoryServiceOptions
@@ -1715,10 +1815,26 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		if err := d.Set("routing", flattenArmStorageAccountRouting(props.RoutingPreference)); err != nil {
 			return fmt.Errorf("setting `routing`: %+v", err)
 		}
+		if err := d.Set("immutability_policy", flattenStorageAccountImmutabilityPolicy(props.ImmutableStorageWithVersioning)); err != nil {
+			return fmt.Errorf("setting `immutability_policy`: %+v", err)
+		}
 		d.Set("enable_https_traffic_only", props.EnableHTTPSTrafficOnly)
 		d.Set("is_hns_enabled", props.IsHnsEnabled)
 		d.Set("nfsv3_enabled", props.EnableNfsV3)
 
+		sftpEnabled := false
+		if props.IsSftpEnabled != nil {
+			sftpEnabled = *props.IsSftpEnabled
+		}
+		d.Set("sftp_enabled", sftpEnabled)
+
+		// local users are enabled by default, and this isn't returned for accounts which predate the feature
+		localUserEnabled := true
+		if props.IsLocalUserEnabled != nil {
+			localUserEnabled = *props.IsLocalUserEnabled
+		}
+		d.Set("local_user_enabled", localUserEnabled)
+
 		if crossTenantReplication := props.AllowCrossTenantReplication; crossTenantReplication != nil {
 			d.Set("cross_tenant_replication_enabled", crossTenantReplication)
 		}
@@ -1968,7 +2084,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	read, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, "")
@@ -2007,11 +2125,18 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("acquiring locks: %+v", err)
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
+		// the API returns an opaque 409 when blobs are still protected by a locked policy, so we surface why this isn't possible
+		if response.WasConflict(resp.Response) && storageAccountHasLockedImmutabilityPolicy(read.AccountProperties) {
+			return fmt.Errorf("deleting %s: the Storage Account has a locked `immutability_policy` and cannot be deleted until all blob versions within it have passed their retention period: %+v", *id, err)
+		}
+
 		if !response.WasNotFound(resp.Response) {
 			return fmt.Errorf("issuing delete request for %s: %+v", *id, err)
 		}
@@ -2216,6 +2341,61 @@ func expandArmStorageAccountRouting(input []interface{}) *storage.RoutingPrefere
 	}
 }
 
+func expandStorageAccountImmutabilityPolicy(input []interface{}) *storage.ImmutableStorageAccount {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+
+	return &storage.ImmutableStorageAccount{
+		Enabled: utils.Bool(true),
+		ImmutabilityPolicy: &storage.AccountImmutabilityPolicyProperties{
+			AllowProtectedAppendWrites:            utils.Bool(v["allow_protected_append_writes"].(bool)),
+			ImmutabilityPeriodSinceCreationInDays: utils.Int32(int32(v["period_since_creation_in_days"].(int))),
+			State:                                 storage.AccountImmutabilityPolicyState(v["state"].(string)),
+		},
+	}
+}
+
+// validateStorageAccountImmutabilityPolicyChange ensures that a locked policy is only ever changed by extending its retention period
+func validateStorageAccountImmutabilityPolicyChange(old, new interface{}) error {
+	oldRaw := old.([]interface{})
+	newRaw := new.([]interface{})
+	if len(oldRaw) == 0 || oldRaw[0] == nil || len(newRaw) == 0 || newRaw[0] == nil {
+		return nil
+	}
+	oldPolicy := oldRaw[0].(map[string]interface{})
+	newPolicy := newRaw[0].(map[string]interface{})
+
+	if oldPolicy["state"].(string) != string(storage.AccountImmutabilityPolicyStateLocked) {
+		return nil
+	}
+
+	if newPolicy["state"].(string) != string(storage.AccountImmutabilityPolicyStateLocked) {
+		return fmt.Errorf("`immutability_policy.0.state` cannot be changed from `Locked` to `%s` - a locked policy cannot be unlocked or disabled", newPolicy["state"].(string))
+	}
+
+	oldPeriod := oldPolicy["period_since_creation_in_days"].(int)
+	newPeriod := newPolicy["period_since_creation_in_days"].(int)
+	if newPeriod < oldPeriod {
+		return fmt.Errorf("`immutability_policy.0.period_since_creation_in_days` cannot be reduced from %d to %d since the policy is locked - the retention period of a locked policy can only be extended", oldPeriod, newPeriod)
+	}
+
+	if oldPolicy["allow_protected_append_writes"].(bool) != newPolicy["allow_protected_append_writes"].(bool) {
+		return fmt.Errorf("`immutability_policy.0.allow_protected_append_writes` cannot be changed since the policy is locked")
+	}
+
+	return nil
+}
+
+func storageAccountHasLockedImmutabilityPolicy(input *storage.AccountProperties) bool {
+	if input == nil || input.ImmutableStorageWithVersioning == nil || input.ImmutableStorageWithVersioning.ImmutabilityPolicy == nil {
+		return false
+	}
+
+	return input.ImmutableStorageWithVersioning.ImmutabilityPolicy.State == storage.AccountImmutabilityPolicyStateLocked
+}
+
 func expandStorageAccountNetworkRules(d *pluginsdk.ResourceData, tenantId string) *storage.NetworkRuleSet {
 	networkRules := d.Get("network_rules").([]interface{})
 	if len(networkRules) == 0 {
@@ -2696,6 +2876,30 @@ func flattenArmStorageAccountRouting(input *storage.RoutingPreference) []interfa
 	}
 }
 
+func flattenStorageAccountImmutabilityPolicy(input *storage.ImmutableStorageAccount) []interface{} {
+	if input == nil || input.Enabled == nil || !*input.Enabled || input.ImmutabilityPolicy == nil {
+		return make([]interface{}, 0)
+	}
+
+	allowProtectedAppendWrites := false
+	if input.ImmutabilityPolicy.AllowProtectedAppendWrites != nil {
+		allowProtectedAppendWrites = *input.ImmutabilityPolicy.AllowProtectedAppendWrites
+	}
+
+	periodSinceCreationInDays := 0
+	if input.ImmutabilityPolicy.ImmutabilityPeriodSinceCreationInDays != nil {
+		periodSinceCreationInDays = int(*input.ImmutabilityPolicy.ImmutabilityPeriodSinceCreationInDays)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"allow_protected_append_writes": allowProtectedAppendWrites,
+			"period_since_creation_in_days": periodSinceCreationInDays,
+			"state":                         string(input.ImmutabilityPolicy.State),
+		},
+	}
+}
+
 func flattenStorageAccountNetworkRules(input *storage.NetworkRuleSet) []interface{} {
 	if input == nil {
 		return []interface{}{}
@@ -0,0 +1,295 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceStorageContainerImmutabilityPolicy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceStorageContainerImmutabilityPolicyCreate,
+		Read:   resourceStorageContainerImmutabilityPolicyRead,
+		Update: resourceStorageContainerImmutabilityPolicyUpdate,
+		Delete: resourceStorageContainerImmutabilityPolicyDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageContainerImmutabilityPolicyID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_container_resource_manager_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageContainerResourceManagerID,
+			},
+
+			"immutability_period_in_days": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 146000),
+			},
+
+			"locked": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"protected_append_writes_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"protected_append_writes_all_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			pluginsdk.CustomizeDiffShim(func(ctx context.Context, d *pluginsdk.ResourceDiff, v interface{}) error {
+				// a locked policy is permanent, the only change the API allows is extending the retention period
+				oldLocked, _ := d.GetChange("locked")
+				if !oldLocked.(bool) {
+					return nil
+				}
+
+				if !d.Get("locked").(bool) {
+					return fmt.Errorf("`locked` cannot be set to `false` once the Immutability Policy has been locked")
+				}
+
+				oldPeriod, newPeriod := d.GetChange("immutability_period_in_days")
+				if newPeriod.(int) < oldPeriod.(int) {
+					return fmt.Errorf("`immutability_period_in_days` cannot be reduced from %d to %d since the Immutability Policy is locked - the retention period of a locked policy can only be extended", oldPeriod.(int), newPeriod.(int))
+				}
+
+				if d.HasChange("protected_append_writes_all_enabled") {
+					return fmt.Errorf("`protected_append_writes_all_enabled` cannot be changed since the Immutability Policy is locked")
+				}
+
+				return nil
+			}),
+		),
+	}
+}
+
+func resourceStorageContainerImmutabilityPolicyCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobContainersClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	containerId, err := parse.StorageContainerResourceManagerID(d.Get("storage_container_resource_manager_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewStorageContainerImmutabilityPolicyID(containerId.SubscriptionId, containerId.ResourceGroup, containerId.StorageAccountName, containerId.BlobServiceName, containerId.ContainerName, "default")
+
+	if err := locks.ByID(ctx, containerId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByID(containerId.ID())
+
+	// the Immutability Policy always exists as a sub-resource of the Container, so the Container is checked instead
+	container, err := client.Get(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *containerId, err)
+	}
+	if props := container.ContainerProperties; props != nil && props.HasImmutabilityPolicy != nil && *props.HasImmutabilityPolicy {
+		return tf.ImportAsExistsError("azurerm_storage_container_immutability_policy", id.ID())
+	}
+
+	policy, err := client.CreateOrUpdateImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, expandStorageContainerImmutabilityPolicy(d), "")
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	if d.Get("locked").(bool) {
+		if err := lockStorageContainerImmutabilityPolicy(ctx, client, id, policy.Etag); err != nil {
+			return err
+		}
+	}
+
+	return resourceStorageContainerImmutabilityPolicyRead(d, meta)
+}
+
+func resourceStorageContainerImmutabilityPolicyUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobContainersClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageContainerImmutabilityPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	containerId := parse.NewStorageContainerResourceManagerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.BlobServiceName, id.ContainerName)
+	if err := locks.ByID(ctx, containerId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByID(containerId.ID())
+
+	existing, err := client.GetImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	if existing.Etag == nil {
+		return fmt.Errorf("retrieving %s: `etag` was nil", *id)
+	}
+
+	if storageContainerImmutabilityPolicyIsLocked(existing) {
+		// the changes allowed to a locked policy are validated at plan time, so the retention period can be extended
+		if _, err := client.ExtendImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, *existing.Etag, expandStorageContainerImmutabilityPolicy(d)); err != nil {
+			return fmt.Errorf("extending %s: %+v", *id, err)
+		}
+
+		return resourceStorageContainerImmutabilityPolicyRead(d, meta)
+	}
+
+	policy, err := client.CreateOrUpdateImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, expandStorageContainerImmutabilityPolicy(d), *existing.Etag)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
+	}
+
+	if d.Get("locked").(bool) {
+		if err := lockStorageContainerImmutabilityPolicy(ctx, client, *id, policy.Etag); err != nil {
+			return err
+		}
+	}
+
+	return resourceStorageContainerImmutabilityPolicyRead(d, meta)
+}
+
+func resourceStorageContainerImmutabilityPolicyRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobContainersClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageContainerImmutabilityPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// when no policy has been configured the API returns an empty policy rather than a 404
+	props := resp.ImmutabilityPolicyProperty
+	if props == nil || props.ImmutabilityPeriodSinceCreationInDays == nil || *props.ImmutabilityPeriodSinceCreationInDays == 0 {
+		log.Printf("[INFO] %s does not exist - removing from state", *id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("storage_container_resource_manager_id", parse.NewStorageContainerResourceManagerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.BlobServiceName, id.ContainerName).ID())
+	d.Set("immutability_period_in_days", int(*props.ImmutabilityPeriodSinceCreationInDays))
+	d.Set("locked", props.State == storage.ImmutabilityPolicyStateLocked)
+	d.Set("protected_append_writes_enabled", props.AllowProtectedAppendWrites != nil && *props.AllowProtectedAppendWrites)
+	d.Set("protected_append_writes_all_enabled", props.AllowProtectedAppendWritesAll != nil && *props.AllowProtectedAppendWritesAll)
+
+	return nil
+}
+
+func resourceStorageContainerImmutabilityPolicyDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobContainersClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageContainerImmutabilityPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	containerId := parse.NewStorageContainerResourceManagerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.BlobServiceName, id.ContainerName)
+	if err := locks.ByID(ctx, containerId.ID()); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
+	defer locks.UnlockByID(containerId.ID())
+
+	existing, err := client.GetImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	if existing.Etag == nil {
+		return fmt.Errorf("retrieving %s: `etag` was nil", *id)
+	}
+
+	// the API returns an opaque 409 when deleting a locked policy, so we surface why this isn't possible instead
+	if storageContainerImmutabilityPolicyIsLocked(existing) {
+		return fmt.Errorf("%s is locked and cannot be deleted: a locked Immutability Policy can only be removed by deleting the Storage Container once all blobs within it have passed their retention period. To stop managing this policy with Terraform, remove it from the state using `terraform state rm`", *id)
+	}
+
+	if resp, err := client.DeleteImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, *existing.Etag); err != nil {
+		if utils.ResponseWasConflict(resp.Response) {
+			return fmt.Errorf("deleting %s: the policy was modified or locked since it was retrieved, please refresh and try again: %+v", *id, err)
+		}
+
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func lockStorageContainerImmutabilityPolicy(ctx context.Context, client *storage.BlobContainersClient, id parse.StorageContainerImmutabilityPolicyId, etag *string) error {
+	if etag == nil {
+		return fmt.Errorf("locking %s: `etag` was nil", id)
+	}
+
+	if _, err := client.LockImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, *etag); err != nil {
+		return fmt.Errorf("locking %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func storageContainerImmutabilityPolicyIsLocked(input storage.ImmutabilityPolicy) bool {
+	return input.ImmutabilityPolicyProperty != nil && input.ImmutabilityPolicyProperty.State == storage.ImmutabilityPolicyStateLocked
+}
+
+func expandStorageContainerImmutabilityPolicy(d *pluginsdk.ResourceData) *storage.ImmutabilityPolicy {
+	return &storage.ImmutabilityPolicy{
+		ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: utils.Int32(int32(d.Get("immutability_period_in_days").(int))),
+			AllowProtectedAppendWrites:            utils.Bool(d.Get("protected_append_writes_enabled").(bool)),
+			AllowProtectedAppendWritesAll:         utils.Bool(d.Get("protected_append_writes_all_enabled").(bool)),
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageContainerImmutabilityPolicyResource struct{}
+
+func TestAccStorageContainerImmutabilityPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("locked").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageContainerImmutabilityPolicy_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageContainerImmutabilityPolicy_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageContainerImmutabilityPolicy_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("immutability_period_in_days").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("immutability_period_in_days").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageContainerImmutabilityPolicyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Storage.BlobContainersClient.GetImmutabilityPolicy(ctx, id.ResourceGroup, id.StorageAccountName, id.ContainerName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	props := resp.ImmutabilityPolicyProperty
+	return utils.Bool(props != nil && props.ImmutabilityPeriodSinceCreationInDays != nil && *props.ImmutabilityPeriodSinceCreationInDays != 0), nil
+}
+
+// NOTE: the policies in these tests are intentionally left unlocked, since a locked policy cannot be deleted
+
+func (r StorageContainerImmutabilityPolicyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  immutability_period_in_days           = 1
+}
+`, r.template(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "import" {
+  storage_container_resource_manager_id = azurerm_storage_container_immutability_policy.test.storage_container_resource_manager_id
+  immutability_period_in_days           = azurerm_storage_container_immutability_policy.test.immutability_period_in_days
+}
+`, r.basic(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  immutability_period_in_days           = 2
+  protected_append_writes_enabled       = true
+  locked                                = false
+}
+`, r.template(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "acctestcontainer%s"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString)
+}
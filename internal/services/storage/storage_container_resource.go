@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/containers"
 )
 
@@ -68,7 +70,7 @@ func resourceStorageContainer() *pluginsdk.Resource {
 
 			"metadata": MetaDataComputedSchema(),
 
-			// TODO: support for ACL's, Legal Holds and Immutability Policies
+			// TODO: support for ACL's
 			"has_immutability_policy": {
 				Type:     pluginsdk.TypeBool,
 				Computed: true,
@@ -260,7 +262,30 @@ func resourceStorageContainerDelete(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
 	}
 
+	// the Data Plane API returns an opaque 409 when the Container is protected by a Legal Hold or a
+	// locked Immutability Policy, so we check for these first to surface why this isn't possible - this
+	// is skipped when the credentials don't have access to the Management Plane API
+	container, err := storageClient.BlobContainersClient.Get(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(container.Response) && !utils.ResponseWasForbidden(container.Response) {
+			return fmt.Errorf("retrieving Container %q (Storage Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+		}
+
+		log.Printf("[DEBUG] Unable to retrieve Container %q (Storage Account %q / Resource Group %q) from the Management Plane API - skipping the Legal Hold and Immutability Policy checks: %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+	if props := container.ContainerProperties; props != nil {
+		if props.HasLegalHold != nil && *props.HasLegalHold {
+			return fmt.Errorf("Container %q (Storage Account %q / Resource Group %q) cannot be deleted since it has a Legal Hold - all Legal Hold tags must be cleared before the Container can be deleted", id.Name, id.AccountName, account.ResourceGroup)
+		}
+	}
+
 	if err := client.Delete(ctx, account.ResourceGroup, id.AccountName, id.Name); err != nil {
+		if props := container.ContainerProperties; props != nil && props.ImmutabilityPolicy != nil && props.ImmutabilityPolicy.ImmutabilityPolicyProperty != nil {
+			if props.ImmutabilityPolicy.ImmutabilityPolicyProperty.State == storage.ImmutabilityPolicyStateLocked {
+				return fmt.Errorf("deleting Container %q (Storage Account %q / Resource Group %q): the Container has a locked Immutability Policy and can only be deleted once all blobs within it have passed their retention period: %s", id.Name, id.AccountName, account.ResourceGroup, err)
+			}
+		}
+
 		return fmt.Errorf("deleting Container %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
 	}
 
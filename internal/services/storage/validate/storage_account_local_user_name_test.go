@@ -0,0 +1,29 @@
+package validate
+
+import "testing"
+
+func TestStorageAccountLocalUserName(t *testing.T) {
+	testCases := []struct {
+		input       string
+		shouldError bool
+	}{
+		{"", true},
+		{"ab", true},
+		{"Partner1", true},
+		{"partner-1", true},
+		{"partner_1", true},
+		{"abc", false},
+		{"partner1", false},
+	}
+
+	for _, test := range testCases {
+		_, es := StorageAccountLocalUserName(test.input, "name")
+
+		if test.shouldError && len(es) == 0 {
+			t.Fatalf("Expected validating name %q to fail", test.input)
+		}
+		if !test.shouldError && len(es) > 0 {
+			t.Fatalf("Expected validating name %q to pass", test.input)
+		}
+	}
+}
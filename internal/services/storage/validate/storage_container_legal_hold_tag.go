@@ -0,0 +1,17 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func StorageContainerLegalHoldTag(v interface{}, _ string) (warnings []string, errors []error) {
+	input := v.(string)
+
+	// the API normalizes Legal Hold tags to lower case, so we require these are specified as such to avoid a diff
+	if !regexp.MustCompile("^[a-z0-9]{3,23}$").MatchString(input) {
+		errors = append(errors, fmt.Errorf("legal hold tag %q must contain only lowercase letters and numbers, and be between 3 and 23 characters", input))
+	}
+
+	return warnings, errors
+}
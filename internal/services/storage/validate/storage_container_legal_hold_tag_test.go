@@ -0,0 +1,30 @@
+package validate
+
+import "testing"
+
+func TestStorageContainerLegalHoldTag(t *testing.T) {
+	testCases := []struct {
+		input       string
+		shouldError bool
+	}{
+		{"", true},
+		{"ab", true},
+		{"Litigation1", true},
+		{"litigation-1", true},
+		{"abcdefghijklmnopqrstuvwx", true},
+		{"abc", false},
+		{"litigation1", false},
+		{"abcdefghijklmnopqrstuvw", false},
+	}
+
+	for _, test := range testCases {
+		_, es := StorageContainerLegalHoldTag(test.input, "tags")
+
+		if test.shouldError && len(es) == 0 {
+			t.Fatalf("Expected validating tag %q to fail", test.input)
+		}
+		if !test.shouldError && len(es) > 0 {
+			t.Fatalf("Expected validating tag %q to pass", test.input)
+		}
+	}
+}
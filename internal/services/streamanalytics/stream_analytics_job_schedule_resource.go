@@ -93,7 +93,9 @@ func (r JobScheduleResource) Create() sdk.ResourceFunc {
 			// This is a virtual resource so the last segment is hardcoded
 			id := parse.NewStreamingJobScheduleID(streamAnalyticsId.SubscriptionId, streamAnalyticsId.ResourceGroup, streamAnalyticsId.Name, "default")
 
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return fmt.Errorf("acquiring lock: %+v", err)
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.Get(ctx, id.ResourceGroup, id.StreamingjobName, "")
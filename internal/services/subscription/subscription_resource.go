@@ -144,7 +144,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return tf.ImportAsExistsError("azurerm_subscription", id.ID())
 	}
 
-	locks.ByName(aliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, aliasName, SubscriptionResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(aliasName, SubscriptionResourceName)
 
 	workload := subscriptionAlias.Production
@@ -165,7 +167,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionIdRaw, ok := d.GetOk("subscription_id"); ok {
 		subscriptionId = subscriptionIdRaw.(string)
 
-		locks.ByID(subscriptionId)
+		if err := locks.ByID(ctx, subscriptionId); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByID(subscriptionId)
 
 		// Terraform assumes a 1:1 mapping between a Subscription and an Alias - first check if there's any existing aliases
@@ -254,7 +258,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.Name, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.Name, SubscriptionResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, SubscriptionResourceName)
 	resp, err := aliasClient.Get(ctx, id.Name)
 	if err != nil || resp.Properties == nil {
@@ -267,7 +273,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	if d.HasChange("subscription_name") {
-		locks.ByID(*subscriptionId)
+		if err := locks.ByID(ctx, *subscriptionId); err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
 		defer locks.UnlockByID(*subscriptionId)
 
 		displayName := subscriptionAlias.Name{
@@ -369,7 +377,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.Name, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.Name, SubscriptionResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Name, SubscriptionResourceName)
 
 	// Get subscription details for later
@@ -381,7 +391,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionIdRaw := alias.Properties.SubscriptionID; subscriptionIdRaw != nil {
 		subscriptionId = *subscriptionIdRaw
 	}
-	locks.ByID(subscriptionId)
+	if err := locks.ByID(ctx, subscriptionId); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByID(subscriptionId)
 
 	sub, err := client.Get(ctx, subscriptionId)
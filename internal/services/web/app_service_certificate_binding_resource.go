@@ -130,7 +130,9 @@ func resourceAppServiceCertificateBindingCreate(d *pluginsdk.ResourceData, meta
 		}
 	}
 
-	locks.ByName(id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName)
+	if err := locks.ByName(ctx, id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName)
 
 	binding.HostNameBindingProperties.SslState = web.SslState(d.Get("ssl_state").(string))
@@ -209,7 +211,9 @@ func resourceAppServiceCertificateBindingDelete(d *pluginsdk.ResourceData, meta
 		return nil
 	}
 
-	locks.ByName(id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName)
+	if err := locks.ByName(ctx, id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.HostnameBindingId.SiteName, appServiceHostnameBindingResourceName)
 
 	log.Printf("[DEBUG] Deleting App Service Hostname Binding %q (App Service %q / Resource Group %q)", id.HostnameBindingId.Name, id.HostnameBindingId.SiteName, id.HostnameBindingId.ResourceGroup)
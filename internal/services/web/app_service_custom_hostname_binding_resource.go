@@ -91,7 +91,9 @@ func resourceAppServiceCustomHostnameBindingCreate(d *pluginsdk.ResourceData, me
 	sslState := d.Get("ssl_state").(string)
 	thumbprint := d.Get("thumbprint").(string)
 
-	locks.ByName(appServiceName, appServiceCustomHostnameBindingResourceName)
+	if err := locks.ByName(ctx, appServiceName, appServiceCustomHostnameBindingResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(appServiceName, appServiceCustomHostnameBindingResourceName)
 
 	if d.IsNewResource() {
@@ -189,7 +191,9 @@ func resourceAppServiceCustomHostnameBindingDelete(d *pluginsdk.ResourceData, me
 		return err
 	}
 
-	locks.ByName(id.AppServiceName, appServiceCustomHostnameBindingResourceName)
+	if err := locks.ByName(ctx, id.AppServiceName, appServiceCustomHostnameBindingResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.AppServiceName, appServiceCustomHostnameBindingResourceName)
 
 	log.Printf("[DEBUG] Deleting App Service Hostname Binding %q (App Service %q / Resource Group %q)", id.Name, id.AppServiceName, id.ResourceGroup)
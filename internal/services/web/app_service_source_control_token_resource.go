@@ -78,7 +78,9 @@ func resourceAppServiceSourceControlTokenCreateUpdate(d *pluginsdk.ResourceData,
 	tokenSecret := d.Get("token_secret").(string)
 	id := parse.NewAppServiceSourceControlTokenID(d.Get("type").(string))
 
-	locks.ByName(id.Type, appServiceSourceControlTokenResourceName)
+	if err := locks.ByName(ctx, id.Type, appServiceSourceControlTokenResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(id.Type, appServiceSourceControlTokenResourceName)
 
 	properties := web.SourceControl{
@@ -134,7 +136,9 @@ func resourceAppServiceSourceControlTokenDelete(d *pluginsdk.ResourceData, meta
 	token := ""
 	tokenSecret := ""
 
-	locks.ByName(scmType, appServiceSourceControlTokenResourceName)
+	if err := locks.ByName(ctx, scmType, appServiceSourceControlTokenResourceName); err != nil {
+		return fmt.Errorf("acquiring lock: %+v", err)
+	}
 	defer locks.UnlockByName(scmType, appServiceSourceControlTokenResourceName)
 
 	log.Printf("[DEBUG] Deleting App Service Source Control Token (Type %q)", scmType)
@@ -0,0 +1,68 @@
+package tags
+
+import "strings"
+
+// MergeDefaults returns the effective Tags for a Resource, which are the `default_tags` defined in
+// the Provider block - overridden by any Tags defined on the Resource itself.
+//
+// Tag Keys are compared case-insensitively, so a Tag defined on the Resource replaces a default
+// Tag whose Key differs only in casing.
+func MergeDefaults(defaults map[string]string, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(defaults)+len(configured))
+
+	configuredKeys := lowerCaseKeys(configured)
+	for k, v := range defaults {
+		if _, ok := configuredKeys[strings.ToLower(k)]; ok {
+			continue
+		}
+		output[k] = v
+	}
+
+	for k, v := range configured {
+		output[k] = v
+	}
+
+	return output
+}
+
+// StripDefaults returns the Tags which should be stored in the `tags` field for a Resource, which
+// are the Tags returned from Azure excluding any which match the `default_tags` defined in the
+// Provider block - so that these don't show as a diff against the Tags defined on the Resource.
+//
+// Tags whose Key is present in `keep` (the Tags defined on the Resource) are retained, since
+// these were explicitly defined on the Resource, even if they match a default Tag.
+func StripDefaults(defaults map[string]string, actual map[string]interface{}, keep map[string]interface{}) map[string]interface{} {
+	if len(defaults) == 0 {
+		return actual
+	}
+
+	defaultValues := make(map[string]string, len(defaults))
+	for k, v := range defaults {
+		defaultValues[strings.ToLower(k)] = v
+	}
+	keepKeys := lowerCaseKeys(keep)
+
+	output := make(map[string]interface{}, len(actual))
+	for k, v := range actual {
+		key := strings.ToLower(k)
+		if _, ok := keepKeys[key]; !ok {
+			if value, isDefault := defaultValues[key]; isDefault {
+				if actualValue, err := TagValueToString(v); err == nil && actualValue == value {
+					continue
+				}
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+func lowerCaseKeys(input map[string]interface{}) map[string]struct{} {
+	output := make(map[string]struct{}, len(input))
+	for k := range input {
+		output[strings.ToLower(k)] = struct{}{}
+	}
+	return output
+}
@@ -0,0 +1,107 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	defaults := map[string]string{
+		"owner":       "platform",
+		"cost-centre": "1234",
+	}
+
+	testData := []struct {
+		name       string
+		configured map[string]interface{}
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "no resource tags",
+			configured: map[string]interface{}{},
+			expected: map[string]interface{}{
+				"owner":       "platform",
+				"cost-centre": "1234",
+			},
+		},
+		{
+			name: "resource tags override",
+			configured: map[string]interface{}{
+				"Owner":       "networking",
+				"environment": "prod",
+			},
+			expected: map[string]interface{}{
+				"Owner":       "networking",
+				"cost-centre": "1234",
+				"environment": "prod",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := MergeDefaults(defaults, v.configured)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestStripDefaults(t *testing.T) {
+	defaults := map[string]string{
+		"owner":       "platform",
+		"cost-centre": "1234",
+	}
+
+	testData := []struct {
+		name     string
+		actual   map[string]interface{}
+		keep     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "defaults removed",
+			actual: map[string]interface{}{
+				"owner":       "platform",
+				"cost-centre": "1234",
+				"environment": "prod",
+			},
+			expected: map[string]interface{}{
+				"environment": "prod",
+			},
+		},
+		{
+			name: "overridden default retained",
+			actual: map[string]interface{}{
+				"Owner":       "networking",
+				"cost-centre": "1234",
+			},
+			expected: map[string]interface{}{
+				"Owner": "networking",
+			},
+		},
+		{
+			name: "default defined on the resource retained",
+			actual: map[string]interface{}{
+				"owner":       "platform",
+				"cost-centre": "1234",
+			},
+			keep: map[string]interface{}{
+				"owner": "platform",
+			},
+			expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := StripDefaults(defaults, v.actual, v.keep)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
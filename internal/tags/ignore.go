@@ -0,0 +1,70 @@
+package tags
+
+import "strings"
+
+// IgnoreConfiguration defines the Tags which are managed outside of Terraform (for example by Azure
+// Policy), which are configured in the `ignore_tags` block within the Provider block
+type IgnoreConfiguration struct {
+	// Keys is a list of Tag Keys which should be ignored
+	Keys []string
+
+	// KeyPrefixes is a list of prefixes, where Tags whose Key starts with any of these should be ignored
+	KeyPrefixes []string
+}
+
+// IsEmpty returns whether any Tags should be ignored
+func (c IgnoreConfiguration) IsEmpty() bool {
+	return len(c.Keys) == 0 && len(c.KeyPrefixes) == 0
+}
+
+// Ignored returns whether the Tag with the specified Key should be ignored, where Keys and prefixes
+// are compared case-insensitively (as they are in Azure)
+func (c IgnoreConfiguration) Ignored(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, v := range c.Keys {
+		if key == strings.ToLower(v) {
+			return true
+		}
+	}
+
+	for _, v := range c.KeyPrefixes {
+		if strings.HasPrefix(key, strings.ToLower(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Remove returns the specified Tags excluding any which should be ignored
+func (c IgnoreConfiguration) Remove(tagsMap map[string]interface{}) map[string]interface{} {
+	if c.IsEmpty() {
+		return tagsMap
+	}
+
+	output := make(map[string]interface{}, len(tagsMap))
+	for k, v := range tagsMap {
+		if !c.Ignored(k) {
+			output[k] = v
+		}
+	}
+
+	return output
+}
+
+// Retain returns only the Tags which should be ignored from the specified Tags
+func (c IgnoreConfiguration) Retain(tagsMap map[string]*string) map[string]interface{} {
+	output := make(map[string]interface{})
+	if c.IsEmpty() {
+		return output
+	}
+
+	for k, v := range tagsMap {
+		if v != nil && c.Ignored(k) {
+			output[k] = *v
+		}
+	}
+
+	return output
+}
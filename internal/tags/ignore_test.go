@@ -0,0 +1,44 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIgnoreConfiguration(t *testing.T) {
+	config := IgnoreConfiguration{
+		Keys:        []string{"CreatedOnDate"},
+		KeyPrefixes: []string{"hidden-"},
+	}
+
+	input := map[string]interface{}{
+		"createdondate":   "2022-01-01",
+		"Hidden-Link:abc": "Resource",
+		"environment":     "prod",
+	}
+
+	expected := map[string]interface{}{
+		"environment": "prod",
+	}
+	if actual := config.Remove(input); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	value := func(input string) *string {
+		return &input
+	}
+	retained := config.Retain(map[string]*string{
+		"CreatedOnDate": value("2022-01-01"),
+		"environment":   value("prod"),
+	})
+	expected = map[string]interface{}{
+		"CreatedOnDate": "2022-01-01",
+	}
+	if !reflect.DeepEqual(retained, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, retained)
+	}
+
+	if actual := (IgnoreConfiguration{}).Remove(input); !reflect.DeepEqual(actual, input) {
+		t.Fatalf("expected no tags to be removed when nothing's ignored but got %+v", actual)
+	}
+}
@@ -0,0 +1,177 @@
+package tags
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Policy defines the organisational rules which the Tags for each Resource must meet, which are
+// configured in the `tag_policy` block within the Provider block and checked at plan time
+type Policy struct {
+	// RequiredKeys is a list of Tag Keys which must be specified on each Resource
+	RequiredKeys []string
+
+	// ForbiddenKeys is a list of Tag Keys which must not be specified on any Resource
+	ForbiddenKeys []string
+
+	// AllowedValues is a map of Tag Key to a regular expression which the value for that Tag must match
+	AllowedValues map[string]*regexp.Regexp
+}
+
+// IsEmpty returns whether this Policy contains any rules
+func (p Policy) IsEmpty() bool {
+	return len(p.RequiredKeys) == 0 && len(p.ForbiddenKeys) == 0 && len(p.AllowedValues) == 0
+}
+
+// Violations returns each of the rules within this Policy which the specified Tags don't meet.
+//
+// Tag Keys are compared case-insensitively (as they are in Azure) - and a nil value denotes a
+// Tag whose value isn't known yet, which is only checked against the required/forbidden keys.
+func (p Policy) Violations(tagsMap map[string]*string) []string {
+	keys := make(map[string]string, len(tagsMap))
+	for k := range tagsMap {
+		keys[strings.ToLower(k)] = k
+	}
+
+	violations := make([]string, 0)
+
+	for _, required := range p.RequiredKeys {
+		if _, ok := keys[strings.ToLower(required)]; !ok {
+			violations = append(violations, fmt.Sprintf("the required tag %q is missing", required))
+		}
+	}
+
+	for _, forbidden := range p.ForbiddenKeys {
+		if k, ok := keys[strings.ToLower(forbidden)]; ok {
+			violations = append(violations, fmt.Sprintf("the tag %q is forbidden", k))
+		}
+	}
+
+	allowedKeys := make([]string, 0, len(p.AllowedValues))
+	for k := range p.AllowedValues {
+		allowedKeys = append(allowedKeys, k)
+	}
+	sort.Strings(allowedKeys)
+
+	for _, allowed := range allowedKeys {
+		k, ok := keys[strings.ToLower(allowed)]
+		if !ok {
+			continue
+		}
+
+		value := tagsMap[k]
+		if value == nil {
+			continue
+		}
+
+		expression := p.AllowedValues[allowed]
+		if !expression.MatchString(*value) {
+			violations = append(violations, fmt.Sprintf("the value %q for the tag %q doesn't match the pattern %q", *value, k, expression.String()))
+		}
+	}
+
+	return violations
+}
+
+// policyReportSummaryDelay is how long the PolicyReport waits after the last Resource is recorded before logging
+// the summary of every offending Resource - since the Provider isn't notified when the plan completes
+const policyReportSummaryDelay = 2 * time.Second
+
+// PolicyReport collects the Policy violations for each Resource planned during this run, so that a summary of
+// every offending Resource (and Tag) can be logged once - in addition to the error returned for each Resource
+type PolicyReport struct {
+	lock sync.Mutex
+
+	// violations is a map of the Resource (its Resource ID, or the type and address when it doesn't exist yet)
+	// to the violations for that Resource
+	violations map[string][]string
+
+	summaryTimer *time.Timer
+}
+
+// NewPolicyReport returns an empty PolicyReport
+func NewPolicyReport() *PolicyReport {
+	return &PolicyReport{
+		violations: make(map[string][]string),
+	}
+}
+
+// Record records the violations for the specified Resource, returning an error listing the violations for this
+// Resource - or nil if this Resource meets the Policy
+func (r *PolicyReport) Record(resource string, violations []string) error {
+	if r != nil {
+		r.lock.Lock()
+		if len(violations) == 0 {
+			delete(r.violations, resource)
+		} else {
+			r.violations[resource] = violations
+		}
+		r.scheduleSummary()
+		r.lock.Unlock()
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, fmt.Sprintf("  - %s", v))
+	}
+	return fmt.Errorf("the tags for %s don't meet the `tag_policy` defined in the Provider block:\n\n%s", resource, strings.Join(lines, "\n"))
+}
+
+// Summary returns a summary of every offending Resource recorded during this run, or an empty string if
+// there aren't any
+func (r *PolicyReport) Summary() string {
+	if r == nil {
+		return ""
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.summary()
+}
+
+// summary returns the summary of every offending Resource, the lock must be held by the caller
+func (r *PolicyReport) summary() string {
+	if len(r.violations) == 0 {
+		return ""
+	}
+
+	resources := make([]string, 0, len(r.violations))
+	for k := range r.violations {
+		resources = append(resources, k)
+	}
+	sort.Strings(resources)
+
+	lines := make([]string, 0)
+	for _, k := range resources {
+		lines = append(lines, fmt.Sprintf("%s:", k))
+		for _, v := range r.violations[k] {
+			lines = append(lines, fmt.Sprintf("  - %s", v))
+		}
+	}
+
+	return fmt.Sprintf("the tags for the following %d resources don't meet the `tag_policy` defined in the Provider block:\n\n%s", len(resources), strings.Join(lines, "\n"))
+}
+
+// scheduleSummary (re)schedules logging the summary once no further Resources have been recorded for
+// policyReportSummaryDelay, the lock must be held by the caller
+func (r *PolicyReport) scheduleSummary() {
+	if r.summaryTimer != nil {
+		r.summaryTimer.Stop()
+	}
+
+	r.summaryTimer = time.AfterFunc(policyReportSummaryDelay, func() {
+		if summary := r.Summary(); summary != "" {
+			log.Printf("[WARN] %s", summary)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package tags
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPolicyViolations(t *testing.T) {
+	policy := Policy{
+		RequiredKeys:  []string{"owner", "cost-centre"},
+		ForbiddenKeys: []string{"legacy"},
+		AllowedValues: map[string]*regexp.Regexp{
+			"environment": regexp.MustCompile("^(dev|test|prod)$"),
+		},
+	}
+
+	value := func(input string) *string {
+		return &input
+	}
+
+	testData := []struct {
+		name     string
+		input    map[string]*string
+		expected []string
+	}{
+		{
+			name: "compliant",
+			input: map[string]*string{
+				"Owner":       value("platform"),
+				"cost-centre": value("1234"),
+				"environment": value("prod"),
+			},
+			expected: []string{},
+		},
+		{
+			name:  "missing required",
+			input: map[string]*string{},
+			expected: []string{
+				`the required tag "owner" is missing`,
+				`the required tag "cost-centre" is missing`,
+			},
+		},
+		{
+			name: "forbidden and invalid value",
+			input: map[string]*string{
+				"owner":       value("platform"),
+				"cost-centre": value("1234"),
+				"Legacy":      value("true"),
+				"Environment": value("staging"),
+			},
+			expected: []string{
+				`the tag "Legacy" is forbidden`,
+				`the value "staging" for the tag "Environment" doesn't match the pattern "^(dev|test|prod)$"`,
+			},
+		},
+		{
+			name: "unknown value",
+			input: map[string]*string{
+				"owner":       value("platform"),
+				"cost-centre": value("1234"),
+				"environment": nil,
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := policy.Violations(v.input)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestPolicyReport(t *testing.T) {
+	report := NewPolicyReport()
+	if err := report.Record(`azurerm_resource_group "example"`, nil); err != nil {
+		t.Fatalf("expected no error when there are no violations but got %+v", err)
+	}
+
+	err := report.Record("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/second", []string{"third"})
+	if err == nil {
+		t.Fatalf("expected an error when there are violations")
+	}
+
+	// the error for each resource only lists the violations for that resource
+	err = report.Record(`azurerm_resource_group "example"`, []string{"first", "second"})
+	if err == nil {
+		t.Fatalf("expected an error when there are violations")
+	}
+	if expected := "  - first\n  - second"; !strings.HasSuffix(err.Error(), expected) || strings.Contains(err.Error(), "third") {
+		t.Fatalf("expected the error to list only the violations for this resource but got %q", err.Error())
+	}
+
+	// whereas the summary lists every offending resource
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/second:\n  - third\nazurerm_resource_group \"example\":\n  - first\n  - second"
+	if summary := report.Summary(); !strings.Contains(summary, expected) {
+		t.Fatalf("expected the summary to list every offending resource and violation but got %q", summary)
+	}
+
+	// once the violations for a resource are fixed, it's no longer reported
+	if err := report.Record("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/second", nil); err != nil {
+		t.Fatalf("expected no error when there are no violations but got %+v", err)
+	}
+	if summary := report.Summary(); strings.Contains(summary, "virtualNetworks") {
+		t.Fatalf("expected only the remaining offending resource to be listed but got %q", summary)
+	}
+}
@@ -77,3 +77,15 @@ func SchemaEnforceLowerCaseKeys() *pluginsdk.Schema {
 		},
 	}
 }
+
+// SchemaTagsAll returns the Schema used for the computed `tags_all` field, which exposes the
+// effective Tags for a Resource - including any `default_tags` defined in the Provider block
+func SchemaTagsAll() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
@@ -0,0 +1,230 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Summary summarises the requests sent to Azure Resource Manager for one or more operations
+type Summary struct {
+	ResourceType string `json:"resource_type,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+
+	// Operations is the number of operations included in this Summary
+	Operations int `json:"operations"`
+
+	Requests  int `json:"requests"`
+	Retries   int `json:"retries"`
+	Throttled int `json:"throttled"`
+	Errors    int `json:"errors"`
+
+	// LatencyMs is the total time spent waiting for a response from Azure Resource Manager
+	LatencyMs int64 `json:"latency_ms"`
+
+	// PollingRequests is the number of requests polling the status of a Long Running Operation
+	PollingRequests int `json:"polling_requests"`
+
+	// PollingMs is the time spent polling Long Running Operations (including the delay between polls)
+	PollingMs int64 `json:"polling_ms"`
+
+	// ElapsedMs is the total time taken for the operations
+	ElapsedMs int64 `json:"elapsed_ms"`
+
+	// ResourceProviders is the number of requests sent to each Resource Provider and API Version,
+	// in the format `Microsoft.Compute@2021-11-01`
+	ResourceProviders map[string]int `json:"resource_providers,omitempty"`
+
+	// StatusCodes is the number of requests returning each HTTP Status Code
+	StatusCodes map[string]int `json:"status_codes,omitempty"`
+}
+
+// Summarise returns a Summary of the requests recorded within this Scope
+func (s *Scope) Summarise() Summary {
+	summary := Summary{
+		ResourceType:      s.ResourceType,
+		Operation:         s.Operation,
+		Operations:        1,
+		PollingMs:         s.pollingDuration().Milliseconds(),
+		ElapsedMs:         time.Since(s.started).Milliseconds(),
+		ResourceProviders: make(map[string]int),
+		StatusCodes:       make(map[string]int),
+	}
+
+	var latency time.Duration
+	for _, request := range s.Requests() {
+		summary.Requests++
+		summary.Retries += request.Retries
+		summary.Throttled += request.Throttled
+		if request.StatusCode == 0 || request.StatusCode >= 400 {
+			summary.Errors++
+		}
+		if request.Polling {
+			summary.PollingRequests++
+		}
+		latency += request.Latency
+
+		provider := request.ResourceProvider
+		if provider == "" {
+			provider = "(none)"
+		}
+		summary.ResourceProviders[fmt.Sprintf("%s@%s", provider, request.ApiVersion)]++
+		summary.StatusCodes[fmt.Sprintf("%d", request.StatusCode)]++
+	}
+	summary.LatencyMs = latency.Milliseconds()
+
+	return summary
+}
+
+// add merges the other Summary into this one
+func (s *Summary) add(other Summary) {
+	s.Operations += other.Operations
+	s.Requests += other.Requests
+	s.Retries += other.Retries
+	s.Throttled += other.Throttled
+	s.Errors += other.Errors
+	s.LatencyMs += other.LatencyMs
+	s.PollingRequests += other.PollingRequests
+	s.PollingMs += other.PollingMs
+	s.ElapsedMs += other.ElapsedMs
+
+	if s.ResourceProviders == nil {
+		s.ResourceProviders = make(map[string]int)
+	}
+	for k, v := range other.ResourceProviders {
+		s.ResourceProviders[k] += v
+	}
+	if s.StatusCodes == nil {
+		s.StatusCodes = make(map[string]int)
+	}
+	for k, v := range other.StatusCodes {
+		s.StatusCodes[k] += v
+	}
+}
+
+// String returns a human-readable representation of this Summary
+func (s Summary) String() string {
+	providers := make([]string, 0, len(s.ResourceProviders))
+	for k, v := range s.ResourceProviders {
+		providers = append(providers, fmt.Sprintf("%s: %d", k, v))
+	}
+	sort.Strings(providers)
+
+	return fmt.Sprintf("%d requests (%d retries, %d throttled, %d errors) waiting %s - %d polling Long Running Operations for %s - total %s [%s]",
+		s.Requests, s.Retries, s.Throttled, s.Errors,
+		time.Duration(s.LatencyMs)*time.Millisecond, s.PollingRequests, time.Duration(s.PollingMs)*time.Millisecond,
+		time.Duration(s.ElapsedMs)*time.Millisecond, strings.Join(providers, ", "))
+}
+
+// RunSummary is the whole-run summary written to the summary file
+type RunSummary struct {
+	Started   time.Time `json:"started"`
+	Updated   time.Time `json:"updated"`
+	Total     Summary   `json:"total"`
+	Resources []Summary `json:"resources"`
+}
+
+// Recorder aggregates the Summary for each operation during this run of the Provider, optionally
+// writing a whole-run summary to a file after each operation completes
+type Recorder struct {
+	summaryFile string
+	started     time.Time
+
+	mu        sync.Mutex
+	summaries map[string]*Summary
+}
+
+// NewRecorder returns a Recorder - which writes a whole-run summary to summaryFile when this is specified
+func NewRecorder(summaryFile string) *Recorder {
+	return &Recorder{
+		summaryFile: summaryFile,
+		started:     time.Now(),
+		summaries:   make(map[string]*Summary),
+	}
+}
+
+// Complete logs a summary of the requests recorded within the specified Scope and includes these in the
+// whole-run summary. This is a no-op when the Recorder is nil.
+func (r *Recorder) Complete(scope *Scope, resourceID string) {
+	if r == nil || scope == nil {
+		return
+	}
+
+	summary := scope.Summarise()
+	if summary.Requests > 0 {
+		log.Printf("[DEBUG] Azure Resource Manager requests for %s %q (%s): %s", scope.ResourceType, resourceID, scope.Operation, summary)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s", scope.ResourceType, scope.Operation)
+	existing, ok := r.summaries[key]
+	if !ok {
+		existing = &Summary{
+			ResourceType: scope.ResourceType,
+			Operation:    scope.Operation,
+		}
+		r.summaries[key] = existing
+	}
+	existing.add(summary)
+
+	if r.summaryFile != "" {
+		if err := r.writeSummaryFile(); err != nil {
+			log.Printf("[WARN] writing the Azure Resource Manager telemetry summary to %q: %+v", r.summaryFile, err)
+		}
+	}
+}
+
+// RunSummary returns the whole-run summary for the operations completed so far
+func (r *Recorder) RunSummary() RunSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.runSummary()
+}
+
+func (r *Recorder) runSummary() RunSummary {
+	keys := make([]string, 0, len(r.summaries))
+	for k := range r.summaries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	output := RunSummary{
+		Started:   r.started,
+		Updated:   time.Now(),
+		Resources: make([]Summary, 0, len(keys)),
+	}
+	for _, k := range keys {
+		summary := *r.summaries[k]
+		output.Resources = append(output.Resources, summary)
+		output.Total.add(summary)
+	}
+
+	return output
+}
+
+// writeSummaryFile (re)writes the whole-run summary, since the Provider isn't notified when a run completes
+func (r *Recorder) writeSummaryFile() error {
+	contents, err := json.MarshalIndent(r.runSummary(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling: %+v", err)
+	}
+
+	// write to a temporary file first so that the summary file is never partially written
+	tempFile := fmt.Sprintf("%s.tmp", r.summaryFile)
+	if err := os.WriteFile(tempFile, contents, 0o644); err != nil {
+		return fmt.Errorf("writing %q: %+v", tempFile, err)
+	}
+	if err := os.Rename(tempFile, r.summaryFile); err != nil {
+		return fmt.Errorf("renaming %q: %+v", tempFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+	recorder := NewRecorder(summaryFile)
+
+	for i := 0; i < 2; i++ {
+		scope := NewScope("azurerm_resource_group", "create")
+		req := &http.Request{
+			Method: http.MethodPut,
+			URL: &url.URL{
+				Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+				RawQuery: "api-version=2020-06-01",
+			},
+		}
+		scope.record(req, &http.Response{StatusCode: http.StatusTooManyRequests}, time.Now(), time.Second)
+		scope.record(req, &http.Response{StatusCode: http.StatusCreated}, time.Now(), time.Second)
+		recorder.Complete(scope, "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+	}
+
+	summary := recorder.RunSummary()
+	if len(summary.Resources) != 1 {
+		t.Fatalf("expected a single resource summary but got %+v", summary.Resources)
+	}
+	resource := summary.Resources[0]
+	if resource.Operations != 2 || resource.Requests != 2 || resource.Retries != 2 || resource.Throttled != 2 || resource.Errors != 0 {
+		t.Fatalf("unexpected summary: %+v", resource)
+	}
+	if resource.LatencyMs != 4000 {
+		t.Fatalf("expected a latency of 4000ms but got %d", resource.LatencyMs)
+	}
+	if resource.StatusCodes["201"] != 2 || resource.ResourceProviders["(none)@2020-06-01"] != 2 {
+		t.Fatalf("unexpected status codes/resource providers: %+v / %+v", resource.StatusCodes, resource.ResourceProviders)
+	}
+
+	contents, err := os.ReadFile(summaryFile)
+	if err != nil {
+		t.Fatalf("reading the summary file: %+v", err)
+	}
+	var written RunSummary
+	if err := json.Unmarshal(contents, &written); err != nil {
+		t.Fatalf("unmarshalling the summary file: %+v", err)
+	}
+	if written.Total.Requests != 2 || len(written.Resources) != 1 {
+		t.Fatalf("unexpected summary file: %s", string(contents))
+	}
+
+	// a nil Recorder is a no-op
+	var nilRecorder *Recorder
+	nilRecorder.Complete(NewScope("azurerm_resource_group", "read"), "")
+}
@@ -0,0 +1,213 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Request is the telemetry recorded for a single request sent to Azure Resource Manager,
+// including any retries of that request
+type Request struct {
+	// Method is the HTTP Method used for this request, e.g. `GET`
+	Method string
+
+	// ResourceProvider is the Resource Provider this request was sent to, e.g. `Microsoft.Compute`
+	ResourceProvider string
+
+	// ApiVersion is the value of the `api-version` query string parameter
+	ApiVersion string
+
+	// StatusCode is the HTTP Status Code returned for the last attempt, or 0 if no response was returned
+	StatusCode int
+
+	// Retries is the number of times this request was retried
+	Retries int
+
+	// Throttled is the number of attempts for this request which returned a 429 (Too Many Requests)
+	Throttled int
+
+	// Latency is the total time spent waiting for a response across each attempt
+	Latency time.Duration
+
+	// Polling specifies whether this request was polling the status of a Long Running Operation
+	Polling bool
+}
+
+// Scope records the requests sent to Azure Resource Manager during a single operation
+// (e.g. a Create) against a single resource
+type Scope struct {
+	// ResourceType is the Terraform Resource Type, e.g. `azurerm_resource_group`
+	ResourceType string
+
+	// Operation is the operation being performed, e.g. `create` or `read`
+	Operation string
+
+	started time.Time
+
+	mu       sync.Mutex
+	requests []*Request
+	attempts map[*http.Request]*Request
+	polling  map[string]*pollingSpan
+}
+
+// pollingSpan is the period of time spent polling a single Long Running Operation
+type pollingSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// NewScope returns a Scope for the specified operation against a resource
+func NewScope(resourceType, operation string) *Scope {
+	return &Scope{
+		ResourceType: resourceType,
+		Operation:    operation,
+		started:      time.Now(),
+		requests:     make([]*Request, 0),
+		attempts:     make(map[*http.Request]*Request),
+		polling:      make(map[string]*pollingSpan),
+	}
+}
+
+// record records a single attempt at sending the specified request - where autorest retries a
+// request by sending the same *http.Request again, which is used to determine the retry count
+func (s *Scope) record(req *http.Request, resp *http.Response, started time.Time, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request, exists := s.attempts[req]
+	if !exists {
+		request = &Request{
+			Method:           req.Method,
+			ResourceProvider: resourceProviderFromURL(req.URL),
+			ApiVersion:       req.URL.Query().Get("api-version"),
+			Polling:          isPollingRequest(req),
+		}
+		s.attempts[req] = request
+		s.requests = append(s.requests, request)
+	} else {
+		request.Retries++
+	}
+
+	request.StatusCode = 0
+	if resp != nil {
+		request.StatusCode = resp.StatusCode
+	}
+	if request.StatusCode == http.StatusTooManyRequests {
+		request.Throttled++
+	}
+	request.Latency += latency
+
+	if request.Polling {
+		// each poll is a new request, so the time spent polling is the period from the first poll to the last
+		key := strings.ToLower(req.URL.Path)
+		span, ok := s.polling[key]
+		if !ok {
+			span = &pollingSpan{
+				start: started,
+			}
+			s.polling[key] = span
+		}
+		span.end = started.Add(latency)
+	}
+}
+
+// Requests returns a copy of the requests recorded within this Scope
+func (s *Scope) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output := make([]Request, 0, len(s.requests))
+	for _, v := range s.requests {
+		output = append(output, *v)
+	}
+	return output
+}
+
+// pollingDuration returns the total time spent polling Long Running Operations within this Scope
+func (s *Scope) pollingDuration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total time.Duration
+	for _, span := range s.polling {
+		total += span.end.Sub(span.start)
+	}
+	return total
+}
+
+type scopeContextKey struct{}
+
+// WithScope returns a copy of the context which records the requests sent using it into the specified Scope
+func WithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// operationScopes maps the state of the resource being operated on (e.g. a `*pluginsdk.ResourceData`) to the
+// Scope for that operation, see WithOperation
+var operationScopes sync.Map
+
+// WithOperation associates the Scope with the state of the resource being operated on until the returned function
+// is called, such that the context for requests can be scoped using ForOperation - since most resources derive this
+// from the Provider's StopContext rather than the context passed to the operation
+func WithOperation(state interface{}, scope *Scope) func() {
+	operationScopes.Store(state, scope)
+	return func() {
+		operationScopes.Delete(state)
+	}
+}
+
+// ForOperation returns a copy of the context which records the requests sent using it into the Scope associated
+// with the state of the resource being operated on (see WithOperation) - or the context when there isn't one
+func ForOperation(ctx context.Context, state interface{}) context.Context {
+	if FromContext(ctx) != nil {
+		return ctx
+	}
+
+	if scope, ok := operationScopes.Load(state); ok {
+		return WithScope(ctx, scope.(*Scope))
+	}
+	return ctx
+}
+
+// FromContext returns the Scope associated with the specified context, or nil if there isn't one
+func FromContext(ctx context.Context) *Scope {
+	if ctx == nil {
+		return nil
+	}
+
+	scope, _ := ctx.Value(scopeContextKey{}).(*Scope)
+	return scope
+}
+
+// resourceProviderFromURL returns the (last) Resource Provider within the specified URL,
+// e.g. `Microsoft.Compute` for `/subscriptions/../providers/Microsoft.Compute/virtualMachines/vm1`
+func resourceProviderFromURL(input *url.URL) string {
+	segments := strings.Split(strings.Trim(input.Path, "/"), "/")
+	provider := ""
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "providers") {
+			provider = segments[i+1]
+		}
+	}
+	return provider
+}
+
+// isPollingRequest returns whether the specified request is polling the status of a Long Running
+// Operation, based on the URL's returned in the `Azure-AsyncOperation` and `Location` headers
+func isPollingRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	path := strings.ToLower(req.URL.Path)
+	for _, v := range []string{"/operations/", "/operationresults/", "/operationstatuses/", "/asyncoperations/"} {
+		if strings.Contains(path, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package telemetry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// Sender returns an autorest.Sender which records each request sent using the specified Sender into
+// the Scope associated with the context for that request (if any) - see WithScope
+func Sender(sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		scope := FromContext(req.Context())
+		if scope == nil {
+			return sender.Do(req)
+		}
+
+		started := time.Now()
+		resp, err := sender.Do(req)
+		scope.record(req, resp, started, time.Since(started))
+		return resp, err
+	})
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestSender(t *testing.T) {
+	statusCodes := []int{http.StatusTooManyRequests, http.StatusOK, http.StatusOK}
+	inner := autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		statusCode := statusCodes[0]
+		statusCodes = statusCodes[1:]
+		return &http.Response{StatusCode: statusCode, Request: req}, nil
+	})
+	sender := Sender(inner)
+
+	scope := NewScope("azurerm_virtual_machine", "create")
+	ctx := WithScope(context.TODO(), scope)
+
+	put, _ := http.NewRequestWithContext(ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/vm1?api-version=2021-11-01", nil)
+	for i := 0; i < 2; i++ {
+		// autorest retries a request by sending the same request again
+		if _, err := sender.Do(put); err != nil {
+			t.Fatalf("sending: %+v", err)
+		}
+	}
+
+	poll, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/locations/westeurope/operations/abc123?api-version=2021-11-01", nil)
+	if _, err := sender.Do(poll); err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+
+	unscoped, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers?api-version=2020-06-01", nil)
+	statusCodes = append(statusCodes, http.StatusOK)
+	if _, err := sender.Do(unscoped); err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+
+	requests := scope.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests but got %d: %+v", len(requests), requests)
+	}
+
+	first := requests[0]
+	if first.Method != http.MethodPut || first.ResourceProvider != "Microsoft.Compute" || first.ApiVersion != "2021-11-01" {
+		t.Fatalf("unexpected request: %+v", first)
+	}
+	if first.StatusCode != http.StatusOK || first.Retries != 1 || first.Throttled != 1 || first.Polling {
+		t.Fatalf("expected one throttled retry ending in a 200 but got %+v", first)
+	}
+	if !requests[1].Polling {
+		t.Fatalf("expected the second request to be polling a Long Running Operation but got %+v", requests[1])
+	}
+}
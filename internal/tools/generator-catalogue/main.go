@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2017-03-09/resources/mgmt/resources"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/catalogue"
+)
+
+// this tool generates the offline Catalogue of Resource Providers and Locations for an Azure Environment,
+// which is embedded into the Provider and used for Enhanced Validation when the Azure API's are unavailable
+//
+// Authentication uses either a Service Principal with a Client Secret (via the `ARM_CLIENT_ID`, `ARM_CLIENT_SECRET`
+// and `ARM_TENANT_ID` Environment Variables) or the Azure CLI - and `ARM_SUBSCRIPTION_ID` must be set.
+
+func main() {
+	outputPath := flag.String("path", "", "The path to the directory where the Catalogue should be written")
+	environment := flag.String("environment", "public", "The Azure Environment to generate the Catalogue for (public, usgovernment or china)")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp {
+		flag.Usage()
+		return
+	}
+
+	if err := run(context.Background(), *outputPath, *environment); err != nil {
+		log.Fatalf("generating the Catalogue: %+v", err)
+	}
+}
+
+func run(ctx context.Context, outputPath, environment string) error {
+	builder := authentication.Builder{
+		SubscriptionID: os.Getenv("ARM_SUBSCRIPTION_ID"),
+		ClientID:       os.Getenv("ARM_CLIENT_ID"),
+		ClientSecret:   os.Getenv("ARM_CLIENT_SECRET"),
+		TenantID:       os.Getenv("ARM_TENANT_ID"),
+		Environment:    environment,
+
+		SupportsAzureCliToken:    true,
+		SupportsClientSecretAuth: true,
+	}
+	config, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("building the authentication config: %+v", err)
+	}
+
+	env, err := authentication.AzureEnvironmentByNameFromEndpoint(ctx, "", environment)
+	if err != nil {
+		return fmt.Errorf("determining the Azure Environment %q: %+v", environment, err)
+	}
+
+	oauthConfig, err := config.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
+	if err != nil {
+		return fmt.Errorf("building the OAuth config: %+v", err)
+	}
+
+	s := sender.BuildSender("AzureRM")
+	authorizer, err := config.GetADALToken(ctx, s, oauthConfig, env.TokenAudience)
+	if err != nil {
+		return fmt.Errorf("obtaining a token for Resource Manager: %+v", err)
+	}
+
+	client := resources.NewProvidersClientWithBaseURI(env.ResourceManagerEndpoint, config.SubscriptionID)
+	client.Authorizer = authorizer
+	client.Sender = s
+
+	output, err := buildCatalogue(ctx, client, environment)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling the Catalogue: %+v", err)
+	}
+
+	fileName := filepath.Join(outputPath, fmt.Sprintf("%s.json", strings.ToLower(environment)))
+	if err := os.WriteFile(fileName, append(contents, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %q: %+v", fileName, err)
+	}
+
+	return nil
+}
+
+func buildCatalogue(ctx context.Context, client resources.ProvidersClient, environment string) (*catalogue.Catalogue, error) {
+	output := catalogue.Catalogue{
+		Version:           time.Now().UTC().Format("2006-01-02"),
+		Environment:       strings.ToLower(environment),
+		ResourceProviders: make([]string, 0),
+	}
+	locations := make(map[string]struct{})
+
+	// the Locations available within this Azure Environment are those which any Resource Type is available in
+	providers, err := client.ListComplete(ctx, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing Resource Providers: %+v", err)
+	}
+	for providers.NotDone() {
+		provider := providers.Value()
+		if provider.Namespace != nil {
+			output.ResourceProviders = append(output.ResourceProviders, *provider.Namespace)
+
+			if provider.ResourceTypes != nil {
+				for _, resourceType := range *provider.ResourceTypes {
+					if resourceType.Locations == nil {
+						continue
+					}
+					for _, location := range *resourceType.Locations {
+						locations[strings.ReplaceAll(strings.ToLower(location), " ", "")] = struct{}{}
+					}
+				}
+			}
+		}
+
+		if err := providers.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Resource Providers: %+v", err)
+		}
+	}
+
+	delete(locations, "")
+	delete(locations, "global")
+	for location := range locations {
+		output.Locations = append(output.Locations, location)
+	}
+	sort.Strings(output.Locations)
+	sort.Strings(output.ResourceProviders)
+
+	return &output, nil
+}